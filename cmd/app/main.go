@@ -90,12 +90,20 @@ func main() {
 	lt := game.NewLobbyTracker(services.HoldemService)
 	o := game.NewWsObserver()
 	b := game.NewBalanceObserver(services.UserService)
+	hist := game.NewHistoryObserver(services.HistoryService)
 	engine := game.NewHoldemEngine(
 		services.HoldemService,
 		o,
 		b,
+		hist,
 		lt,
 	)
+	if grace := os.Getenv("RECONNECT_GRACE"); grace != "" {
+		engine.ReconnectGrace, err = time.ParseDuration(grace)
+		if err != nil {
+			logrus.Fatalf("Error while parse reconnect grace: %s", err.Error())
+		}
+	}
 	h := handlers.NewHandler(services, engine)
 	srv := server.NewServer(h)
 
@@ -0,0 +1,2 @@
+drop table hand_players;
+drop table hands;
\ No newline at end of file
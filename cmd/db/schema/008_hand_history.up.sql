@@ -0,0 +1,15 @@
+create table hands(
+    id bigserial primary key,
+    table_id uuid not null,
+    game_type varchar(32) not null,
+    pot int not null,
+    rake int default 0 not null,
+    started_at timestamptz not null,
+    finished_at timestamptz not null,
+    data jsonb not null
+);
+create table hand_players(
+    hand_id bigint not null references hands(id) on delete cascade,
+    user_id uuid not null references users(id),
+    primary key (user_id, hand_id)
+);
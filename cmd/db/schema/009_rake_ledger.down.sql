@@ -0,0 +1 @@
+drop table rake_ledger;
\ No newline at end of file
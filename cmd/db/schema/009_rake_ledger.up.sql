@@ -0,0 +1,7 @@
+create table rake_ledger(
+    id bigserial primary key,
+    hand_id bigint not null references hands(id) on delete cascade,
+    table_id uuid not null,
+    amount int not null,
+    created_at timestamptz not null
+);
@@ -0,0 +1 @@
+drop index hands_table_hand;
\ No newline at end of file
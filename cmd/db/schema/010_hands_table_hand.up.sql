@@ -0,0 +1 @@
+create index hands_table_hand on hands(table_id, ((data->>'hand')::int));
@@ -16,16 +16,12 @@ func NewBalanceObserver(s user.IUserService) *BalanceObserver {
 }
 
 func (bo *BalanceObserver) Update(recipients []string, data holdem.ObserverMessage) {
-	if data.EventType == "players_stats" {
-		players, ok := data.EventData.([]holdem.IPlayer)
-		if !ok {
-			return
-		}
-		ids := make([]uuid.UUID, 0, len(players))
-		balance := make([]int, 0, len(players))
-		for _, p := range players {
-			ids = append(ids, uuid.MustParse(p.GetId()))
-			balance = append(balance, p.GetBalance())
+	if stats, ok := data.EventData.(holdem.PlayersStats); ok {
+		ids := make([]uuid.UUID, 0, len(stats.Players))
+		balance := make([]int, 0, len(stats.Players))
+		for _, p := range stats.Players {
+			ids = append(ids, uuid.MustParse(p.Id))
+			balance = append(balance, p.Balance)
 		}
 		err := bo.s.UpdateManyUserBalance(ids, balance)
 		if err != nil {
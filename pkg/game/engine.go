@@ -1,34 +1,56 @@
 package game
 
 import (
+	"sync"
+	"time"
+
+	"github.com/SanyaWarvar/poker/pkg/holdem"
+	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 )
 
+const DefaultReconnectGrace = time.Second * 60
+
 type PlayerMove struct {
 	PlayerId uuid.UUID
 	LobbyId  uuid.UUID
 	Action   string `json:"action" binding:"reqired"`
 	Amount   int    `json:"amount" binding:"reqired"`
+	Seed     string `json:"seed"` // только для action = client_seed
 }
 
 type HoldemEngine struct {
 	service    IHoldemService
 	WsObserver *WsObserver
 	BObserver  *BalanceObserver
+	HObserver  *HistoryObserver
 	Lt         *LobbyTracker
+
+	ReconnectGrace time.Duration // сколько место ждет отключившегося игрока. 0 - игрок выходит из лобби сразу
+	mu             sync.Mutex
+	disconnects    map[lobbySeat]*time.Timer // места отключившихся игроков, которые пока держатся
 }
 
-func NewHoldemEngine(s IHoldemService, o *WsObserver, b *BalanceObserver, lt *LobbyTracker) *HoldemEngine {
+type lobbySeat struct {
+	lobbyId  uuid.UUID
+	playerId uuid.UUID
+}
+
+func NewHoldemEngine(s IHoldemService, o *WsObserver, b *BalanceObserver, h *HistoryObserver, lt *LobbyTracker) *HoldemEngine {
 	return &HoldemEngine{
 		service:    s,
 		WsObserver: o,
 		BObserver:  b,
+		HObserver:  h,
 		Lt:         lt,
+
+		ReconnectGrace: DefaultReconnectGrace,
+		disconnects:    map[lobbySeat]*time.Timer{},
 	}
 }
 
 func (e *HoldemEngine) NewLobby(lId, pId uuid.UUID, lInfo LobbyInfo) {
-	e.Lt.lobbies[lId.String()] = lInfo
+	e.Lt.AddLobby(lId, lInfo)
 }
 
 func (e *HoldemEngine) AddPlayer(lId, pId uuid.UUID) bool {
@@ -36,12 +58,90 @@ func (e *HoldemEngine) AddPlayer(lId, pId uuid.UUID) bool {
 }
 
 func (e *HoldemEngine) HandleMove(move PlayerMove) {
-	e.service.DoAction(move.PlayerId, move.LobbyId, move.Action, move.Amount)
+	switch move.Action {
+	case holdem.ActionClientSeed:
+		e.service.SetClientSeed(move.PlayerId, move.LobbyId, move.Seed)
+	case holdem.ActionRunIt:
+		e.service.RunItVote(move.PlayerId, move.LobbyId, move.Amount)
+	case holdem.ActionBombPot:
+		e.service.RequestBombPot(move.PlayerId, move.LobbyId)
+	case holdem.ActionSitOut:
+		e.service.SitOut(move.PlayerId, move.LobbyId)
+	case holdem.ActionSitIn:
+		e.service.SitIn(move.PlayerId, move.LobbyId, move.Amount != 0)
+	case holdem.ActionShow:
+		e.service.ShowCards(move.PlayerId, move.LobbyId)
+	case holdem.ActionState:
+		e.SendState(move.LobbyId, move.PlayerId)
+	default:
+		e.service.DoAction(move.PlayerId, move.LobbyId, move.Action, move.Amount)
+	}
 }
 
-func (e *HoldemEngine) OutFromLobby(lobbyId, playerId uuid.UUID) error {
-	if e.Lt.lobbies[lobbyId.String()].PlayersCount <= 1 {
-		delete(e.Lt.lobbies, lobbyId.String())
+// SendState отправляет игроку снимок стола, чужие карты в нем скрыты
+func (e *HoldemEngine) SendState(lobbyId, playerId uuid.UUID) error {
+	state, err := e.service.GetState(playerId, lobbyId)
+	if err != nil {
+		return err
+	}
+	e.WsObserver.Broadcast([]string{playerId.String()}, holdem.ObserverMessage{
+		EventType: holdem.EventState,
+		EventData: state,
+		LobbyId:   lobbyId.String(),
+		Version:   holdem.EventSchemaVersion,
+	})
+	return nil
+}
+
+// Disconnect держит место отключившегося игрока ReconnectGrace и только потом выводит его из лобби.
+// Стол об отключении не знает, так что таймер хода идет как обычно. Если время вышло во время раздачи,
+// игрок сбрасывает карты и пересаживается в сторону, а место освобождается после раздачи
+func (e *HoldemEngine) Disconnect(lobbyId, playerId uuid.UUID) {
+	if e.ReconnectGrace <= 0 {
+		e.leave(lobbyId, playerId)
+		return
 	}
+	key := lobbySeat{lobbyId: lobbyId, playerId: playerId}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if timer, ok := e.disconnects[key]; ok {
+		timer.Stop()
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(e.ReconnectGrace, func() {
+		e.mu.Lock()
+		if e.disconnects[key] != timer {
+			e.mu.Unlock()
+			return
+		}
+		delete(e.disconnects, key)
+		e.mu.Unlock()
+		e.leave(lobbyId, playerId)
+	})
+	e.disconnects[key] = timer
+}
+
+// Reconnect возвращает игрока на место, которое держится после отключения. false - места нет или время вышло
+func (e *HoldemEngine) Reconnect(lobbyId, playerId uuid.UUID) bool {
+	key := lobbySeat{lobbyId: lobbyId, playerId: playerId}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	timer, ok := e.disconnects[key]
+	if !ok || !timer.Stop() {
+		return false
+	}
+	delete(e.disconnects, key)
+	return true
+}
+
+func (e *HoldemEngine) leave(lobbyId, playerId uuid.UUID) {
+	e.Lt.RemovePlayer(lobbyId)
+	if err := e.service.LeaveLobby(lobbyId, playerId); err != nil {
+		log.Warnf("leave: e.service.LeaveLobby: %s", err.Error())
+	}
+}
+
+func (e *HoldemEngine) OutFromLobby(lobbyId, playerId uuid.UUID) error {
+	e.Lt.RemovePlayer(lobbyId)
 	return e.service.OutFromLobby(lobbyId, playerId)
 }
@@ -0,0 +1,22 @@
+package game
+
+import (
+	"github.com/SanyaWarvar/poker/pkg/history"
+	"github.com/SanyaWarvar/poker/pkg/holdem"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// HistoryObserver сохраняет каждую сыгранную раздачу
+type HistoryObserver struct {
+	*holdem.HandRecorder
+}
+
+func NewHistoryObserver(s history.IHistoryService) *HistoryObserver {
+	return &HistoryObserver{
+		HandRecorder: holdem.NewHandRecorder(func(h holdem.HandHistory) {
+			if err := s.SaveHand(h); err != nil {
+				log.Warnf("s.SaveHand: %s", err.Error())
+			}
+		}),
+	}
+}
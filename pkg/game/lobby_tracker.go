@@ -3,7 +3,6 @@ package game
 import (
 	"fmt"
 	"slices"
-	"strings"
 	"sync"
 	"time"
 
@@ -13,9 +12,10 @@ import (
 )
 
 const (
-	DefaultTimeout = time.Second * 15
-	DefaultTTL     = time.Second * 30
-	DefaultTTS     = time.Second * 10
+	DefaultTimeout  = time.Second * 15
+	DefaultTimeBank = time.Second * 30
+	DefaultTTL      = time.Second * 30
+	DefaultTTS      = time.Second * 10
 )
 
 type LobbyInfo struct {
@@ -34,7 +34,7 @@ type LobbyTracker struct {
 	timeouts map[string]struct{}
 }
 
-var LobbyTrackerEventTypes = []string{"game_started", "next_move", "do", "game created", "game started", "stop_game"}
+var LobbyTrackerEventTypes = []string{holdem.EventGameStarted, holdem.EventNextMove, holdem.EventDo, holdem.EventStopGame}
 
 func NewLobbyTracker(s IHoldemService) *LobbyTracker {
 	return &LobbyTracker{
@@ -49,20 +49,12 @@ func (lt *LobbyTracker) Update(recipients []string, data holdem.ObserverMessage)
 	if !slices.Contains(LobbyTrackerEventTypes, data.EventType) {
 		return
 	}
-	s, ok := data.EventData.(string)
-	if !ok {
-		return
-	}
-	msg := strings.Split(s, " ")
-
-	id := msg[1]
-
-	if data.EventType == "stop_game" {
+	if _, ok := data.EventData.(holdem.GameStopped); ok {
 		lt.mu.Lock()
-		item := lt.lobbies[id]
+		item := lt.lobbies[data.LobbyId]
 		item.GameStarted = false
 		item.LastActivity = time.Now()
-		lt.lobbies[id] = item
+		lt.lobbies[data.LobbyId] = item
 		lt.mu.Unlock()
 	}
 }
@@ -113,3 +105,25 @@ func (lt *LobbyTracker) AddPlayer(lId uuid.UUID) bool {
 	lt.mu.Unlock()
 	return true
 }
+
+func (lt *LobbyTracker) AddLobby(lId uuid.UUID, lInfo LobbyInfo) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	lt.lobbies[lId.String()] = lInfo
+}
+
+// RemovePlayer - игрок вышел из лобби. С последним игроком лобби перестает отслеживаться
+func (lt *LobbyTracker) RemovePlayer(lId uuid.UUID) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	l, ok := lt.lobbies[lId.String()]
+	if !ok {
+		return
+	}
+	if l.PlayersCount <= 1 {
+		delete(lt.lobbies, lId.String())
+		return
+	}
+	l.PlayersCount -= 1
+	lt.lobbies[lId.String()] = l
+}
@@ -22,9 +22,19 @@ type IHoldemRepo interface {
 	GetLobbyList(page int) []holdem.TableConfig
 	GetLobbyById(lobbyId uuid.UUID) (holdem.TableConfig, error)
 	GetLobbyByPId(playerId uuid.UUID) (holdem.TableConfig, error)
-	EnterInLobby(lobbyId uuid.UUID, player holdem.IPlayer) error
+	EnterInLobby(lobbyId uuid.UUID, player holdem.IPlayer, seat int) error
 	OutFromLobby(lobbyId, playerId uuid.UUID) error
+	LeaveLobby(lobbyId, playerId uuid.UUID) error
 	DoAction(playerId, lobbyId uuid.UUID, action string, amount int) error
+	SetClientSeed(playerId, lobbyId uuid.UUID, seed string) error
+	RunItVote(playerId, lobbyId uuid.UUID, runs int) error
+	RequestBombPot(playerId, lobbyId uuid.UUID) error
+	SitOut(playerId, lobbyId uuid.UUID) error
+	SitIn(playerId, lobbyId uuid.UUID, postMissed bool) error
+	ShowCards(playerId, lobbyId uuid.UUID) error
+	GetState(playerId, lobbyId uuid.UUID) (holdem.TableState, error)
+	AddSpectator(lobbyId, spectatorId uuid.UUID) error
+	RemoveSpectator(lobbyId, spectatorId uuid.UUID) error
 	DeleteLobby(lobbyId uuid.UUID)
 	AddObserver(lobbyId uuid.UUID, observer holdem.IObserver) error
 	StartGame(lobbyId uuid.UUID) error
@@ -97,12 +107,12 @@ func (r *HoldemRepo) GetLobbyByPId(playerId uuid.UUID) (holdem.TableConfig, erro
 	return output, ErrLobbyNotFound
 }
 
-func (r *HoldemRepo) EnterInLobby(lobbyId uuid.UUID, player holdem.IPlayer) error {
+func (r *HoldemRepo) EnterInLobby(lobbyId uuid.UUID, player holdem.IPlayer, seat int) error {
 	lobby, ok := r.db[lobbyId.String()]
 	if !ok {
 		return ErrLobbyNotFound
 	}
-	err := lobby.AddPlayer(player)
+	err := lobby.AddPlayerAt(player, seat)
 	return err
 }
 
@@ -115,6 +125,14 @@ func (r *HoldemRepo) OutFromLobby(lobbyId, playerId uuid.UUID) error {
 	return err
 }
 
+func (r *HoldemRepo) LeaveLobby(lobbyId, playerId uuid.UUID) error {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.Leave(playerId.String())
+}
+
 func (r *HoldemRepo) DoAction(playerId, lobbyId uuid.UUID, action string, amount int) error {
 	lobby, ok := r.db[lobbyId.String()]
 	if !ok {
@@ -124,6 +142,78 @@ func (r *HoldemRepo) DoAction(playerId, lobbyId uuid.UUID, action string, amount
 	return err
 }
 
+func (r *HoldemRepo) SetClientSeed(playerId, lobbyId uuid.UUID, seed string) error {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.SetClientSeed(playerId.String(), seed)
+}
+
+func (r *HoldemRepo) RunItVote(playerId, lobbyId uuid.UUID, runs int) error {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.RunItVote(playerId.String(), runs)
+}
+
+func (r *HoldemRepo) RequestBombPot(playerId, lobbyId uuid.UUID) error {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.RequestBombPot(playerId.String())
+}
+
+func (r *HoldemRepo) SitOut(playerId, lobbyId uuid.UUID) error {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.SitOut(playerId.String())
+}
+
+func (r *HoldemRepo) SitIn(playerId, lobbyId uuid.UUID, postMissed bool) error {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.SitIn(playerId.String(), postMissed)
+}
+
+func (r *HoldemRepo) ShowCards(playerId, lobbyId uuid.UUID) error {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.ShowCards(playerId.String())
+}
+
+func (r *HoldemRepo) GetState(playerId, lobbyId uuid.UUID) (holdem.TableState, error) {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return holdem.TableState{}, ErrLobbyNotFound
+	}
+	return lobby.GetState(playerId.String()), nil
+}
+
+func (r *HoldemRepo) AddSpectator(lobbyId, spectatorId uuid.UUID) error {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.AddSpectator(spectatorId.String())
+}
+
+func (r *HoldemRepo) RemoveSpectator(lobbyId, spectatorId uuid.UUID) error {
+	lobby, ok := r.db[lobbyId.String()]
+	if !ok {
+		return ErrLobbyNotFound
+	}
+	return lobby.RemoveSpectator(spectatorId.String())
+}
+
 func (r *HoldemRepo) DeleteLobby(lobbyId uuid.UUID) {
 	delete(r.db, lobbyId.String())
 	ind := slices.Index(r.list, lobbyId.String())
@@ -15,12 +15,24 @@ type IHoldemService interface {
 	GetLobbyList(page int) ([]LobbyOutput, error)
 	GetLobbyById(lobbyId uuid.UUID) (LobbyOutput, error)
 	GetLobbyByPId(playerId uuid.UUID) (LobbyOutput, error)
-	EnterInLobby(lobbyId, playerId uuid.UUID, balance int) error
+	EnterInLobby(lobbyId, playerId uuid.UUID, balance, seat int) error
 	OutFromLobby(lobbyId, playerId uuid.UUID) error
+	LeaveLobby(lobbyId, playerId uuid.UUID) error
 	DoAction(playerId, lobbyId uuid.UUID, action string, amount int) error
+	SetClientSeed(playerId, lobbyId uuid.UUID, seed string) error
+	RunItVote(playerId, lobbyId uuid.UUID, runs int) error
+	RequestBombPot(playerId, lobbyId uuid.UUID) error
+	SitOut(playerId, lobbyId uuid.UUID) error
+	SitIn(playerId, lobbyId uuid.UUID, postMissed bool) error
+	ShowCards(playerId, lobbyId uuid.UUID) error
+	GetState(playerId, lobbyId uuid.UUID) (holdem.TableState, error)
+	AddSpectator(lobbyId, spectatorId uuid.UUID) error
+	RemoveSpectator(lobbyId, spectatorId uuid.UUID) error
 	AddObserver(lobbyId uuid.UUID, observer holdem.IObserver) error
 	StartGame(lobbyId uuid.UUID) error
 	DeleteLobby(lobbyId uuid.UUID)
+	CalculateEquity(req holdem.EquityRequest) (holdem.EquityResult, error)
+	VerifyShuffle(proof holdem.ShuffleProof) ([]holdem.Card, error)
 }
 
 type HoldemService struct {
@@ -117,7 +129,7 @@ func (s *HoldemService) GetLobbyByPId(playerId uuid.UUID) (LobbyOutput, error) {
 }
 
 // TODO change this
-func (s *HoldemService) EnterInLobby(lobbyId, playerId uuid.UUID, balance int) error {
+func (s *HoldemService) EnterInLobby(lobbyId, playerId uuid.UUID, balance, seat int) error {
 	/*_, err := s.GetLobbyByPId(playerId)
 	if err != ErrLobbyNotFound {
 		return errors.New("player already in lobby")
@@ -131,19 +143,23 @@ func (s *HoldemService) EnterInLobby(lobbyId, playerId uuid.UUID, balance int) e
 		Balance: balance,
 		Status:  false,
 		LastBet: 0,
-		Hand:    holdem.Hand{Cards: [2]holdem.Card{}},
+		Hand:    holdem.Hand{},
 		IsFold:  false,
 	}
 	if lobby.Info.BankAmount != 0 {
 		p.Balance = lobby.Info.BankAmount
 	}
-	return s.holdemRepo.EnterInLobby(lobbyId, p)
+	return s.holdemRepo.EnterInLobby(lobbyId, p, seat)
 }
 
 func (s *HoldemService) OutFromLobby(lobbyId, playerId uuid.UUID) error {
 	return s.holdemRepo.OutFromLobby(lobbyId, playerId)
 }
 
+func (s *HoldemService) LeaveLobby(lobbyId, playerId uuid.UUID) error {
+	return s.holdemRepo.LeaveLobby(lobbyId, playerId)
+}
+
 func (s *HoldemService) AddObserver(lobbyId uuid.UUID, observer holdem.IObserver) error {
 	return s.holdemRepo.AddObserver(lobbyId, observer)
 }
@@ -152,9 +168,53 @@ func (s *HoldemService) DoAction(playerId, lobbyId uuid.UUID, action string, amo
 	return s.holdemRepo.DoAction(playerId, lobbyId, action, amount)
 }
 
+func (s *HoldemService) SetClientSeed(playerId, lobbyId uuid.UUID, seed string) error {
+	return s.holdemRepo.SetClientSeed(playerId, lobbyId, seed)
+}
+
+func (s *HoldemService) RunItVote(playerId, lobbyId uuid.UUID, runs int) error {
+	return s.holdemRepo.RunItVote(playerId, lobbyId, runs)
+}
+
+func (s *HoldemService) RequestBombPot(playerId, lobbyId uuid.UUID) error {
+	return s.holdemRepo.RequestBombPot(playerId, lobbyId)
+}
+
+func (s *HoldemService) SitOut(playerId, lobbyId uuid.UUID) error {
+	return s.holdemRepo.SitOut(playerId, lobbyId)
+}
+
+func (s *HoldemService) SitIn(playerId, lobbyId uuid.UUID, postMissed bool) error {
+	return s.holdemRepo.SitIn(playerId, lobbyId, postMissed)
+}
+
+func (s *HoldemService) ShowCards(playerId, lobbyId uuid.UUID) error {
+	return s.holdemRepo.ShowCards(playerId, lobbyId)
+}
+
+func (s *HoldemService) GetState(playerId, lobbyId uuid.UUID) (holdem.TableState, error) {
+	return s.holdemRepo.GetState(playerId, lobbyId)
+}
+
+func (s *HoldemService) AddSpectator(lobbyId, spectatorId uuid.UUID) error {
+	return s.holdemRepo.AddSpectator(lobbyId, spectatorId)
+}
+
+func (s *HoldemService) RemoveSpectator(lobbyId, spectatorId uuid.UUID) error {
+	return s.holdemRepo.RemoveSpectator(lobbyId, spectatorId)
+}
+
 func (s *HoldemService) StartGame(lobbyId uuid.UUID) error {
 	return s.holdemRepo.StartGame(lobbyId)
 }
 func (s *HoldemService) DeleteLobby(lobbyId uuid.UUID) {
 	s.holdemRepo.DeleteLobby(lobbyId)
 }
+
+func (s *HoldemService) CalculateEquity(req holdem.EquityRequest) (holdem.EquityResult, error) {
+	return holdem.CalculateEquity(req)
+}
+
+func (s *HoldemService) VerifyShuffle(proof holdem.ShuffleProof) ([]holdem.Card, error) {
+	return holdem.VerifyShuffle(proof)
+}
@@ -1,20 +1,72 @@
 package game
 
 import (
+	"sync"
+
 	"github.com/SanyaWarvar/poker/pkg/holdem"
 	"github.com/gofiber/websocket/v2"
+	"github.com/google/uuid"
 )
 
 var WsObserverEventTypes = []string{}
 
+// connKey - соединение пользователя с одним лобби. Игрок за одним столом может смотреть другой
+type connKey struct {
+	lobbyId string
+	userId  string
+}
+
+// WsConn - соединение с клиентом. В него пишут и стол, и обработчик соединения, а websocket
+// не допускает одновременной записи, поэтому каждая запись идет под мьютексом соединения
+type WsConn struct {
+	*websocket.Conn
+	mu sync.Mutex
+}
+
+func NewWsConn(c *websocket.Conn) *WsConn {
+	return &WsConn{Conn: c}
+}
+
+func (c *WsConn) WriteJSON(v any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Conn.WriteJSON(v)
+}
+
+func (c *WsConn) WriteMessage(messageType int, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Conn.WriteMessage(messageType, data)
+}
+
 type WsObserver struct {
-	Conn map[string]*websocket.Conn
+	mu   sync.RWMutex
+	conn map[connKey]*WsConn
 }
 
 func NewWsObserver() *WsObserver {
 	return &WsObserver{
-		Conn: map[string]*websocket.Conn{},
+		conn: map[connKey]*WsConn{},
+	}
+}
+
+// Attach запоминает соединение пользователя с лобби, заменяя прежнее
+func (o *WsObserver) Attach(lobbyId, userId uuid.UUID, c *WsConn) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.conn[connKey{lobbyId: lobbyId.String(), userId: userId.String()}] = c
+}
+
+// Detach забывает соединение, только если его еще не заменили новым. Возвращает false, если заменили
+func (o *WsObserver) Detach(lobbyId, userId uuid.UUID, c *WsConn) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	key := connKey{lobbyId: lobbyId.String(), userId: userId.String()}
+	if o.conn[key] != c {
+		return false
 	}
+	delete(o.conn, key)
+	return true
 }
 
 func (o *WsObserver) Update(recipients []string, data holdem.ObserverMessage) {
@@ -24,8 +76,10 @@ func (o *WsObserver) Update(recipients []string, data holdem.ObserverMessage) {
 }
 
 func (o *WsObserver) Broadcast(recipients []string, data holdem.ObserverMessage) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
 	for _, recipient := range recipients {
-		c, ok := o.Conn[recipient]
+		c, ok := o.conn[connKey{lobbyId: data.LobbyId, userId: recipient}]
 		if !ok {
 			continue
 		}
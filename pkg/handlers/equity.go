@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	_ "github.com/SanyaWarvar/poker/docs"
+	"github.com/SanyaWarvar/poker/pkg/holdem"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CalculateEquity
+// @Summary Посчитать шансы рук
+// @Description Шансы на победу и ничью для двух и более рук или диапазонов рук при известных картах стола и вышедших картах.
+// @Description Если раздач немного, считается полным перебором, иначе методом Монте-Карло (iterations, по умолчанию 100000, не больше 1000000). Рук не больше 10
+// @Security ApiAuth
+// @Tags equity
+// @Accept json
+// @Produce json
+// @Param body body holdem.EquityRequest true "Руки, стол и вышедшие карты"
+// @Success 200 {object} holdem.EquityResult "Шансы рук в процентах"
+// @Failure 400 {object} map[string]string "Неверные карты или диапазон, слишком много рук или iterations"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Router /equity/ [post]
+func (h *Handler) CalculateEquity(c *fiber.Ctx) error {
+	var input holdem.EquityRequest
+	err := c.BodyParser(&input)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	res, err := h.services.HoldemService.CalculateEquity(input)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	return c.Status(http.StatusOK).JSON(res)
+}
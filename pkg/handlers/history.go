@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	_ "github.com/SanyaWarvar/poker/docs"
+	"github.com/SanyaWarvar/poker/pkg/history"
+	"github.com/SanyaWarvar/poker/pkg/holdem"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetMyHands
+// @Summary Получить свои последние раздачи
+// @Description История раздач игрока, начиная с последней, с пагинацией (размер страницы - 50).
+// @Description Чужие карты приходят, только если их открыли на вскрытии
+// @Security ApiAuth
+// @Tags hands
+// @Produce json
+// @Param page path int true "Номер страницы" minimum(0)
+// @Success 200 {object} []holdem.HandHistory "Раздачи"
+// @Failure 400 {object} map[string]string "Неверный параметр страницы"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Router /hands/all/{page} [get]
+func (h *Handler) GetMyHands(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(uuid.UUID)
+	if !ok {
+		return ErrorResponse(c, http.StatusUnauthorized, "bad user id")
+	}
+	page, err := c.ParamsInt("page")
+	if err != nil || page < 0 {
+		return ErrorResponse(c, http.StatusBadRequest, "bad page param")
+	}
+	hands, err := h.services.HistoryService.GetRecentHands(userId, page)
+	if err != nil {
+		return ErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+	return c.Status(http.StatusOK).JSON(hands)
+}
+
+// GetHand
+// @Summary Получить раздачу
+// @Description Раздача, в которой участвовал игрок. Чужие карты приходят, только если их открыли на вскрытии
+// @Security ApiAuth
+// @Tags hands
+// @Produce json
+// @Param id path int true "Номер раздачи"
+// @Success 200 {object} holdem.HandHistory "Раздача"
+// @Failure 400 {object} map[string]string "Неверный номер раздачи"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 404 {object} map[string]string "hand not found"
+// @Router /hands/{id} [get]
+func (h *Handler) GetHand(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(uuid.UUID)
+	if !ok {
+		return ErrorResponse(c, http.StatusUnauthorized, "bad user id")
+	}
+	handId, err := c.ParamsInt("id")
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, "bad hand id")
+	}
+	hand, err := h.services.HistoryService.GetHand(int64(handId), userId)
+	if err != nil {
+		return handErrorResponse(c, err)
+	}
+	return c.Status(http.StatusOK).JSON(hand)
+}
+
+// ExportHand
+// @Summary Выгрузить раздачу в формате PokerStars
+// @Description Текст раздачи в формате PokerStars от лица игрока, для импорта в трекеры
+// @Security ApiAuth
+// @Tags hands
+// @Produce plain
+// @Param id path int true "Номер раздачи"
+// @Success 200 {string} string "Раздача в формате PokerStars"
+// @Failure 400 {object} map[string]string "Неверный номер раздачи"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 404 {object} map[string]string "hand not found"
+// @Router /hands/{id}/pokerstars [get]
+func (h *Handler) ExportHand(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(uuid.UUID)
+	if !ok {
+		return ErrorResponse(c, http.StatusUnauthorized, "bad user id")
+	}
+	handId, err := c.ParamsInt("id")
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, "bad hand id")
+	}
+	text, err := h.services.HistoryService.ExportHand(int64(handId), userId)
+	if err != nil {
+		return handErrorResponse(c, err)
+	}
+	return c.Status(http.StatusOK).SendString(text)
+}
+
+// ReplayHand
+// @Summary Повтор раздачи
+// @Description Раздача заново разыгрывается по сиду колоды и записанным ходам. Приходят сообщения стола в исходном порядке,
+// @Description но только те, что получал игрок. offset - секунды от начала раздачи. consistent - совпал ли результат с записанным
+// @Security ApiAuth
+// @Tags hands
+// @Produce json
+// @Param id path int true "Номер раздачи"
+// @Success 200 {object} holdem.ReplayResult "Повтор раздачи"
+// @Failure 400 {object} map[string]string "Неверный номер раздачи"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Failure 404 {object} map[string]string "hand not found или hand has no replay log"
+// @Router /hands/{id}/replay [get]
+func (h *Handler) ReplayHand(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(uuid.UUID)
+	if !ok {
+		return ErrorResponse(c, http.StatusUnauthorized, "bad user id")
+	}
+	handId, err := c.ParamsInt("id")
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, "bad hand id")
+	}
+	res, err := h.services.HistoryService.ReplayHand(int64(handId), userId)
+	if err != nil {
+		return handErrorResponse(c, err)
+	}
+	return c.Status(http.StatusOK).JSON(res)
+}
+
+func handErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, history.ErrHandNotFound) || errors.Is(err, holdem.ErrNoReplayLog) {
+		return ErrorResponse(c, http.StatusNotFound, err.Error())
+	}
+	return ErrorResponse(c, http.StatusInternalServerError, err.Error())
+}
@@ -60,11 +60,28 @@ func (h *Handler) GetAllLobbies(c *fiber.Ctx) error {
 // TableConfigInput
 // @Schema
 type TableConfigInput struct {
-	BlindIncreaseTime string `json:"blind_increase_time" binding:"reqired" example:"15m"`
-	MaxPlayers        int    `json:"max_players" binding:"reqired" example:"7"`
-	SmallBlind        int    `json:"small_blind" binding:"reqired" example:"100"`
-	Ante              int    `json:"ante" example:"25"`
-	BankAmount        int    `json:"bank_amount"`
+	BlindIncreaseTime string              `json:"blind_increase_time" binding:"reqired" example:"15m"`
+	MaxPlayers        int                 `json:"max_players" binding:"reqired" example:"7"`
+	SmallBlind        int                 `json:"small_blind" binding:"reqired" example:"100"`
+	Ante              int                 `json:"ante" example:"25"`
+	BankAmount        int                 `json:"bank_amount"`
+	MoveTimeout       string              `json:"move_timeout" example:"15s"`
+	TimeBank          string              `json:"time_bank" example:"30s"`
+	BlindStructure    string              `json:"blind_structure" example:"regular"` // regular, turbo, hyper. Игнорируется, если переданы blind_levels. Без него и blind_levels блайнды в кэш игре не растут
+	BlindLevels       []holdem.BlindLevel `json:"blind_levels"`
+	GameType          string              `json:"game_type" example:"holdem"`           // holdem, omaha, omaha_hilo, short_deck
+	BettingStructure  string              `json:"betting_structure" example:"no_limit"` // no_limit, pot_limit, fixed_limit. По умолчанию pot_limit для omaha
+	RaiseCap          int                 `json:"raise_cap" example:"4"`                // только для fixed_limit
+	ButtonBlind       bool                `json:"button_blind" example:"false"`         // вместо малого и большого блайнда только блайнд дилера
+	RunItMax          int                 `json:"run_it_max" example:"2"`               // до скольки раз можно прогнать борд, когда все в all in. 0 - нельзя, максимум 3
+	Straddle          bool                `json:"straddle" example:"false"`             // страддл в два больших блайнда, кроме fixed_limit и button_blind
+	BombPotAnte       int                 `json:"bomb_pot_ante" example:"200"`          // анте бомб-пота. 0 - без бомб-потов
+	BombPotEvery      int                 `json:"bomb_pot_every" example:"10"`          // бомб-пот каждые N раздач. 0 - только по просьбе создателя лобби
+	SitOutTimeouts    int                 `json:"sit_out_timeouts" example:"2"`         // после скольких ходов по таймеру подряд игрок пропускает раздачи. 0 - никогда
+	MaxSpectators     int                 `json:"max_spectators" example:"20"`          // сколько зрителей может смотреть игру. 0 - без ограничения
+	RakePercent       float64             `json:"rake_percent" example:"5"`             // рейк в процентах от банка, от 0 до 10. 0 - без рейка
+	RakeCap           int                 `json:"rake_cap" example:"600"`               // максимум рейка с одной раздачи. 0 - без ограничения
+	NoFlopNoDrop      bool                `json:"no_flop_no_drop" example:"true"`       // не брать рейк с раздач, закончившихся до флопа
 }
 
 // CreateLobby
@@ -94,6 +111,14 @@ func (h *Handler) CreateLobby(c *fiber.Ctx) error {
 	if err != nil {
 		return ErrorResponse(c, http.StatusBadRequest, "bad user id")
 	}
+	moveTimeout, err := parseDurationOr(input.MoveTimeout, game.DefaultTimeout)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, "bad move timeout")
+	}
+	timeBank, err := parseDurationOr(input.TimeBank, game.DefaultTimeBank)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, "bad time bank")
+	}
 	cfg := holdem.NewTableConfig(
 		blindsIncreaseTime,
 		input.MaxPlayers,
@@ -104,6 +129,40 @@ func (h *Handler) CreateLobby(c *fiber.Ctx) error {
 		true,
 		0,
 	)
+	cfg.MoveTimeout = moveTimeout
+	cfg.TimeBank = timeBank
+	err = cfg.SetBlindStructure(input.BlindStructure, input.BlindLevels)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	err = cfg.SetGameType(input.GameType)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	err = cfg.SetBettingStructure(input.BettingStructure, input.RaiseCap)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	cfg.ButtonBlind = input.ButtonBlind
+	err = cfg.SetRunItMax(input.RunItMax)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	err = cfg.SetStraddle(input.Straddle)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	err = cfg.SetBombPot(input.BombPotAnte, input.BombPotEvery)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	err = cfg.SetRake(input.RakePercent, input.RakeCap, input.NoFlopNoDrop)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	cfg.HostId = userId
+	cfg.SitOutTimeouts = max(input.SitOutTimeouts, 0)
+	cfg.MaxSpectators = max(input.MaxSpectators, 0)
 
 	lobbyId, err := h.services.HoldemService.CreateLobby(cfg, userId)
 	if err != nil {
@@ -112,6 +171,7 @@ func (h *Handler) CreateLobby(c *fiber.Ctx) error {
 	h.services.HoldemService.AddObserver(lobbyId, h.engine.WsObserver)
 	h.services.HoldemService.AddObserver(lobbyId, h.engine.Lt)
 	h.services.HoldemService.AddObserver(lobbyId, h.engine.BObserver)
+	h.services.HoldemService.AddObserver(lobbyId, h.engine.HObserver)
 	h.engine.NewLobby(lobbyId, userId, game.LobbyInfo{
 		GameStarted:  false,
 		PlayersCount: 0,
@@ -124,6 +184,13 @@ func (h *Handler) CreateLobby(c *fiber.Ctx) error {
 	return c.Status(http.StatusCreated).JSON(map[string]string{"lobby_id": lobbyId.String()})
 }
 
+func parseDurationOr(value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	return time.ParseDuration(value)
+}
+
 // LobbyIdInput
 // @Schema
 type LobbyIdInput struct {
@@ -4,6 +4,7 @@ import (
 	"github.com/SanyaWarvar/poker/pkg/auth"
 	emailsmtp "github.com/SanyaWarvar/poker/pkg/email_smtp"
 	"github.com/SanyaWarvar/poker/pkg/game"
+	"github.com/SanyaWarvar/poker/pkg/history"
 	"github.com/SanyaWarvar/poker/pkg/notifications"
 	"github.com/SanyaWarvar/poker/pkg/user"
 	"github.com/jmoiron/sqlx"
@@ -18,6 +19,7 @@ type Repository struct {
 	EmailSmtpCacheRepo emailsmtp.IEmailCacheRepo
 	HoldemRepo         game.IHoldemRepo
 	NotificationRepo   notifications.INotificationRepository
+	HistoryRepo        history.IHistoryRepo
 }
 
 func NewRepository(
@@ -35,5 +37,6 @@ func NewRepository(
 		EmailSmtpCacheRepo: emailsmtp.NewEmailCacheRepo(cacheDb, emailCfg.CodeExp),
 		HoldemRepo:         game.NewHoldemRepo(),
 		NotificationRepo:   notifications.NewNotificationsPostgres(db),
+		HistoryRepo:        history.NewHistoryPostgres(db),
 	}
 }
@@ -20,7 +20,12 @@ func ErrorResponse(c *fiber.Ctx, statusCode int, message string) error {
 	return c.Status(statusCode).JSON(ErrorResponseStruct{Message: message})
 }
 
-func WsErrorResponse(c *websocket.Conn, messageType int, message string) error {
+// IWsWriter - соединение, в которое можно написать ответ
+type IWsWriter interface {
+	WriteMessage(messageType int, data []byte) error
+}
+
+func WsErrorResponse(c IWsWriter, messageType int, message string) error {
 	logrus.Error(message)
 	data, err := json.Marshal(ErrorResponseStruct{Message: message})
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"github.com/SanyaWarvar/poker/pkg/auth"
 	emailsmtp "github.com/SanyaWarvar/poker/pkg/email_smtp"
 	"github.com/SanyaWarvar/poker/pkg/game"
+	"github.com/SanyaWarvar/poker/pkg/history"
 	"github.com/SanyaWarvar/poker/pkg/notifications"
 	"github.com/SanyaWarvar/poker/pkg/user"
 )
@@ -14,6 +15,7 @@ type Service struct {
 	EmailSmtpService    emailsmtp.IEmailSmtpService
 	HoldemService       game.IHoldemService
 	NotificationService notifications.INotificationService
+	HistoryService      history.IHistoryService
 }
 
 func NewService(repos *Repository) *Service {
@@ -23,5 +25,6 @@ func NewService(repos *Repository) *Service {
 		EmailSmtpService:    emailsmtp.NewEmailSmtpService(repos.EmailSmtpRepo, repos.EmailSmtpCacheRepo),
 		HoldemService:       game.NewHoldemService(repos.HoldemRepo, repos.UserRepo),
 		NotificationService: notifications.NewNotificationService(repos.NotificationRepo),
+		HistoryService:      history.NewHistoryService(repos.HistoryRepo, repos.UserRepo),
 	}
 }
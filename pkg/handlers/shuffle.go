@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	_ "github.com/SanyaWarvar/poker/docs"
+	"github.com/SanyaWarvar/poker/pkg/holdem"
+	"github.com/gofiber/fiber/v2"
+)
+
+// VerifyShuffle
+// @Summary Проверить перемешивание колоды
+// @Description Проверяет, что раскрытый после раздачи сид сервера совпадает с хешем, опубликованным до раздачи,
+// @Description и возвращает колоду в порядке сдачи. Данные для проверки приходят в событии shuffle_reveal и в истории раздачи (shuffle)
+// @Security ApiAuth
+// @Tags shuffle
+// @Accept json
+// @Produce json
+// @Param body body holdem.ShuffleProof true "Сид сервера, хеш и сиды игроков"
+// @Success 200 {object} []holdem.Card "Колода в порядке сдачи"
+// @Failure 400 {object} map[string]string "Сид не совпадает с хешем или неверные данные"
+// @Failure 401 {object} map[string]string "Не авторизован"
+// @Router /shuffle/verify [post]
+func (h *Handler) VerifyShuffle(c *fiber.Ctx) error {
+	var input holdem.ShuffleProof
+	err := c.BodyParser(&input)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	deck, err := h.services.HoldemService.VerifyShuffle(input)
+	if err != nil {
+		return ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+	return c.Status(http.StatusOK).JSON(deck)
+}
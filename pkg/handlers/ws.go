@@ -2,89 +2,90 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2/log"
 
 	_ "github.com/SanyaWarvar/poker/docs"
 	"github.com/SanyaWarvar/poker/pkg/game"
+	"github.com/SanyaWarvar/poker/pkg/holdem"
 	"github.com/gofiber/websocket/v2"
 	"github.com/google/uuid"
 )
 
 func (h *Handler) EnterInLobby(c *websocket.Conn) {
+	conn := game.NewWsConn(c)
 	_, msg, err := c.ReadMessage()
 	if err != nil {
-		WsErrorResponse(c, websocket.CloseMessage, err.Error())
+		WsErrorResponse(conn, websocket.CloseMessage, err.Error())
 		return
 	}
 	token, err := h.services.JwtService.ParseToken(string(msg), true)
 	if err != nil {
-		WsErrorResponse(c, websocket.CloseMessage, err.Error())
+		WsErrorResponse(conn, websocket.CloseMessage, err.Error())
 		return
 	}
 	userId := token.UserId
 	lobbyID, err := uuid.Parse(c.Query("lobby_id"))
 	if err != nil {
-		WsErrorResponse(c, websocket.CloseMessage, "no or invalid lobby id")
+		WsErrorResponse(conn, websocket.CloseMessage, "no or invalid lobby id")
 		return
 	}
-	_, err = h.services.HoldemService.GetLobbyById(lobbyID)
-	if err != nil {
-		WsErrorResponse(c, websocket.CloseMessage, err.Error())
-		return
+	seat := holdem.AnySeat
+	if c.Query("seat") != "" {
+		seat, err = strconv.Atoi(c.Query("seat"))
+		if err != nil {
+			WsErrorResponse(conn, websocket.CloseMessage, "invalid seat")
+			return
+		}
 	}
-	user, err := h.services.UserService.GetUserById(userId)
+	_, err = h.services.HoldemService.GetLobbyById(lobbyID)
 	if err != nil {
-		WsErrorResponse(c, websocket.CloseMessage, err.Error())
+		WsErrorResponse(conn, websocket.CloseMessage, err.Error())
 		return
 	}
-	err = h.services.HoldemService.EnterInLobby(lobbyID, userId, user.Balance)
-	if err != nil {
-		WsErrorResponse(c, websocket.CloseMessage, err.Error())
-		return
+	// переподключившийся вовремя игрок возвращается на свое место
+	reconnected := h.engine.Reconnect(lobbyID, userId)
+	if !reconnected {
+		user, err := h.services.UserService.GetUserById(userId)
+		if err != nil {
+			WsErrorResponse(conn, websocket.CloseMessage, err.Error())
+			return
+		}
+		err = h.services.HoldemService.EnterInLobby(lobbyID, userId, user.Balance, seat)
+		// старое соединение еще не успело закрыться, игрок по-прежнему сидит за столом
+		if errors.Is(err, holdem.ErrAlreadySeated) {
+			reconnected = true
+		} else if err != nil {
+			WsErrorResponse(conn, websocket.CloseMessage, err.Error())
+			return
+		}
 	}
-	h.engine.WsObserver.Conn[userId.String()] = c
-	ok := h.engine.AddPlayer(lobbyID, userId)
-	if !ok {
-		WsErrorResponse(c, websocket.CloseMessage, "cant enter")
+	h.engine.WsObserver.Attach(lobbyID, userId, conn)
+	if !reconnected && !h.engine.AddPlayer(lobbyID, userId) {
+		WsErrorResponse(conn, websocket.CloseMessage, "cant enter")
 		return
 	}
 	lInfo, err := h.services.HoldemService.GetLobbyById(lobbyID)
 	fmt.Println("linfo", lInfo, len(lInfo.Players))
 	if err != nil {
-		WsErrorResponse(c, websocket.CloseMessage, err.Error())
+		WsErrorResponse(conn, websocket.CloseMessage, err.Error())
 		return
 	}
 	for ind, v := range lInfo.Players {
 		v.GenerateUrl()
 		lInfo.Players[ind] = v
 	}
-	c.WriteJSON(lInfo)
+	conn.WriteJSON(lInfo)
+	if err := h.engine.SendState(lobbyID, userId); err != nil {
+		log.Warnf("EnterInLobby: h.engine.SendState: %s", err.Error())
+	}
 	done := make(chan struct{})
-
-	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				if err := c.WriteMessage(websocket.PingMessage, nil); err != nil {
-					return
-				}
-			case <-done:
-				return
-			}
-		}
-	}()
-
-	c.SetPongHandler(func(string) error {
-		fmt.Println("Received pong")
-		return nil
-	})
-	go h.handleDisconnect(c, userId, lobbyID, done)
+	keepAlive(conn, done)
+	go h.handleDisconnect(conn, userId, lobbyID, done)
 	for {
 		var pMove game.PlayerMove
 		_, msg, err := c.ReadMessage()
@@ -97,7 +98,7 @@ func (h *Handler) EnterInLobby(c *websocket.Conn) {
 		}
 		err = json.Unmarshal(msg, &pMove)
 		if err != nil {
-			WsErrorResponse(c, websocket.TextMessage, err.Error())
+			WsErrorResponse(conn, websocket.TextMessage, err.Error())
 		}
 		pMove.PlayerId = userId
 		pMove.LobbyId = lobbyID
@@ -105,13 +106,96 @@ func (h *Handler) EnterInLobby(c *websocket.Conn) {
 	}
 }
 
-func (h *Handler) handleDisconnect(c *websocket.Conn, userId uuid.UUID, lobbyID uuid.UUID, done chan struct{}) {
+func (h *Handler) handleDisconnect(c *game.WsConn, userId uuid.UUID, lobbyID uuid.UUID, done chan struct{}) {
 	<-done
 
-	delete(h.engine.WsObserver.Conn, userId.String())
+	if !h.engine.WsObserver.Detach(lobbyID, userId, c) { // игрок уже переподключился
+		return
+	}
+	h.engine.Disconnect(lobbyID, userId)
+}
 
-	err := h.engine.OutFromLobby(lobbyID, userId)
+// WatchLobby - зритель получает открытые события стола и снимок стола без чужих карт. Ходить он не может,
+// но может запросить снимок заново, отправив { action: state }
+func (h *Handler) WatchLobby(c *websocket.Conn) {
+	conn := game.NewWsConn(c)
+	_, msg, err := c.ReadMessage()
+	if err != nil {
+		WsErrorResponse(conn, websocket.CloseMessage, err.Error())
+		return
+	}
+	token, err := h.services.JwtService.ParseToken(string(msg), true)
 	if err != nil {
-		log.Warnf("handleDisconnect: h.services.HoldemService.OutFromLobby: %s", err.Error())
+		WsErrorResponse(conn, websocket.CloseMessage, err.Error())
+		return
 	}
+	userId := token.UserId
+	lobbyID, err := uuid.Parse(c.Query("lobby_id"))
+	if err != nil {
+		WsErrorResponse(conn, websocket.CloseMessage, "no or invalid lobby id")
+		return
+	}
+	err = h.services.HoldemService.AddSpectator(lobbyID, userId)
+	if err != nil {
+		WsErrorResponse(conn, websocket.CloseMessage, err.Error())
+		return
+	}
+	h.engine.WsObserver.Attach(lobbyID, userId, conn)
+	if err := h.engine.SendState(lobbyID, userId); err != nil {
+		log.Warnf("WatchLobby: h.engine.SendState: %s", err.Error())
+	}
+	done := make(chan struct{})
+	keepAlive(conn, done)
+	go h.handleStopWatching(conn, userId, lobbyID, done)
+	for {
+		var pMove game.PlayerMove
+		_, msg, err := c.ReadMessage()
+		if err != nil {
+			close(done)
+			return
+		}
+		if err := json.Unmarshal(msg, &pMove); err != nil {
+			WsErrorResponse(conn, websocket.TextMessage, err.Error())
+			continue
+		}
+		if pMove.Action == holdem.ActionState {
+			h.engine.SendState(lobbyID, userId)
+		}
+	}
+}
+
+func (h *Handler) handleStopWatching(c *game.WsConn, userId uuid.UUID, lobbyID uuid.UUID, done chan struct{}) {
+	<-done
+
+	if !h.engine.WsObserver.Detach(lobbyID, userId, c) { // зритель сел за стол
+		return
+	}
+	err := h.services.HoldemService.RemoveSpectator(lobbyID, userId)
+	if err != nil {
+		log.Warnf("handleStopWatching: h.services.HoldemService.RemoveSpectator: %s", err.Error())
+	}
+}
+
+// keepAlive пингует клиента, пока соединение не закрыто
+func keepAlive(c *game.WsConn, done chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.WriteMessage(websocket.PingMessage, nil); err != nil {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	c.SetPongHandler(func(string) error {
+		fmt.Println("Received pong")
+		return nil
+	})
 }
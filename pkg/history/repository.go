@@ -0,0 +1,122 @@
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/SanyaWarvar/poker/pkg/holdem"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const pageSize = 50
+
+type IHistoryRepo interface {
+	SaveHand(h holdem.HandHistory) (int64, error)
+	GetHandsByUserId(userId uuid.UUID, page int) ([]holdem.HandHistory, error)
+	GetHandById(handId int64) (holdem.HandHistory, error)
+}
+
+type HistoryPostgres struct {
+	db *sqlx.DB
+}
+
+func NewHistoryPostgres(db *sqlx.DB) *HistoryPostgres {
+	return &HistoryPostgres{db: db}
+}
+
+type handRow struct {
+	Id   int64  `db:"id"`
+	Data []byte `db:"data"`
+}
+
+func (row handRow) toHistory() (holdem.HandHistory, error) {
+	var h holdem.HandHistory
+	err := json.Unmarshal(row.Data, &h)
+	h.Id = row.Id
+	return h, err
+}
+
+// SaveHand сохраняет раздачу. Раздача, которая уже есть (тот же стол и номер), перезаписывается:
+// так в нее попадают карты, открытые после раздачи
+func (r *HistoryPostgres) SaveHand(h holdem.HandHistory) (int64, error) {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	var id int64
+	query := `UPDATE hands SET data = $1 WHERE table_id = $2 AND (data->>'hand')::int = $3 RETURNING id`
+	err = tx.QueryRow(query, data, h.TableId, h.Hand).Scan(&id)
+	if err == nil {
+		return id, tx.Commit()
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return 0, err
+	}
+	query = `
+		INSERT INTO hands(table_id, game_type, pot, rake, started_at, finished_at, data)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+	`
+	err = tx.QueryRow(query, h.TableId, h.GameType, h.Pot, h.Rake, h.StartedAt, h.FinishedAt, data).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	query = `INSERT INTO hand_players(hand_id, user_id) VALUES ($1, $2)`
+	for _, s := range h.Seats {
+		_, err = tx.Exec(query, id, s.PlayerId)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+	if h.Rake != 0 {
+		query = `INSERT INTO rake_ledger(hand_id, table_id, amount, created_at) VALUES ($1, $2, $3, $4)`
+		_, err = tx.Exec(query, id, h.TableId, h.Rake, h.FinishedAt)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+	return id, tx.Commit()
+}
+
+func (r *HistoryPostgres) GetHandsByUserId(userId uuid.UUID, page int) ([]holdem.HandHistory, error) {
+	query := `
+		SELECT h.id, h.data FROM hands h
+		JOIN hand_players hp ON hp.hand_id = h.id
+		WHERE hp.user_id = $1
+		ORDER BY h.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	var rows []handRow
+	err := r.db.Select(&rows, query, userId, pageSize, page*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]holdem.HandHistory, 0, len(rows))
+	for _, row := range rows {
+		h, err := row.toHistory()
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, h)
+	}
+	return output, nil
+}
+
+func (r *HistoryPostgres) GetHandById(handId int64) (holdem.HandHistory, error) {
+	query := `SELECT id, data FROM hands WHERE id = $1`
+	var row handRow
+	err := r.db.Get(&row, query, handId)
+	if err != nil {
+		return holdem.HandHistory{}, err
+	}
+	return row.toHistory()
+}
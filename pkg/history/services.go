@@ -0,0 +1,111 @@
+package history
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SanyaWarvar/poker/pkg/holdem"
+	"github.com/SanyaWarvar/poker/pkg/user"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrHandNotFound = errors.New("hand not found")
+)
+
+type IHistoryService interface {
+	SaveHand(h holdem.HandHistory) error
+	GetRecentHands(userId uuid.UUID, page int) ([]holdem.HandHistory, error)
+	GetHand(handId int64, userId uuid.UUID) (holdem.HandHistory, error)
+	ExportHand(handId int64, userId uuid.UUID) (string, error)
+	ReplayHand(handId int64, userId uuid.UUID) (holdem.ReplayResult, error)
+}
+
+type HistoryService struct {
+	repo     IHistoryRepo
+	userRepo user.IUserRepo
+}
+
+func NewHistoryService(repo IHistoryRepo, userRepo user.IUserRepo) *HistoryService {
+	return &HistoryService{repo: repo, userRepo: userRepo}
+}
+
+func (s *HistoryService) SaveHand(h holdem.HandHistory) error {
+	_, err := s.repo.SaveHand(h)
+	return err
+}
+
+// GetRecentHands возвращает последние раздачи игрока. Чужие карты видны, только если их открыли на вскрытии
+func (s *HistoryService) GetRecentHands(userId uuid.UUID, page int) ([]holdem.HandHistory, error) {
+	hands, err := s.repo.GetHandsByUserId(userId, page)
+	if err != nil {
+		return nil, err
+	}
+	for ind := range hands {
+		hands[ind] = hands[ind].ForPlayer(userId.String())
+	}
+	return hands, nil
+}
+
+// GetHand возвращает раздачу, если игрок в ней участвовал
+func (s *HistoryService) GetHand(handId int64, userId uuid.UUID) (holdem.HandHistory, error) {
+	h, err := s.playerHand(handId, userId)
+	if err != nil {
+		return h, err
+	}
+	return h.ForPlayer(userId.String()), nil
+}
+
+// playerHand возвращает полную запись раздачи со всеми картами и сидом, если игрок в ней участвовал
+func (s *HistoryService) playerHand(handId int64, userId uuid.UUID) (holdem.HandHistory, error) {
+	h, err := s.repo.GetHandById(handId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return h, ErrHandNotFound
+	}
+	if err != nil {
+		return h, err
+	}
+	if _, ok := h.Seat(userId.String()); !ok {
+		return holdem.HandHistory{}, ErrHandNotFound
+	}
+	return h, nil
+}
+
+// ExportHand записывает раздачу в формате PokerStars от лица игрока
+func (s *HistoryService) ExportHand(handId int64, userId uuid.UUID) (string, error) {
+	h, err := s.GetHand(handId, userId)
+	if err != nil {
+		return "", err
+	}
+	ids := make([]uuid.UUID, 0, len(h.Seats))
+	for _, seat := range h.Seats {
+		id, err := uuid.Parse(seat.PlayerId)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	users, err := s.userRepo.GetPlayersByIdLIst(ids)
+	if err != nil {
+		return "", err
+	}
+	names := make(map[string]string, len(users))
+	for _, u := range users {
+		names[u.Id.String()] = u.Username
+	}
+	return holdem.ExportPokerStars(h, userId.String(), names), nil
+}
+
+// ReplayHand заново разыгрывает раздачу по сиду и записанным ходам. Игрок получает только те сообщения,
+// которые приходили ему за столом
+func (s *HistoryService) ReplayHand(handId int64, userId uuid.UUID) (holdem.ReplayResult, error) {
+	h, err := s.playerHand(handId, userId)
+	if err != nil {
+		return holdem.ReplayResult{}, err
+	}
+	res, err := holdem.ReplayHand(h)
+	if err != nil {
+		return holdem.ReplayResult{}, err
+	}
+	return res.ForPlayer(userId.String()), nil
+}
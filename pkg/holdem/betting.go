@@ -0,0 +1,102 @@
+package holdem
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownBettingStructure = errors.New("unknown betting structure")
+	ErrRaiseTooBig             = errors.New("raise exceeds the limit of the betting structure")
+	ErrRaiseCap                = errors.New("raise cap for this round is reached")
+)
+
+const (
+	BettingNoLimit    = "no_limit"
+	BettingPotLimit   = "pot_limit"
+	BettingFixedLimit = "fixed_limit"
+
+	DefaultRaiseCap = 4 // bet и три рейза
+)
+
+// BetRangeError сообщает игроку, до скольки он может поднять ставку
+type BetRangeError struct {
+	Err error
+	Min int
+	Max int
+}
+
+func (e *BetRangeError) Error() string {
+	return fmt.Sprintf("%s: allowed from %d to %d", e.Err.Error(), e.Min, e.Max)
+}
+
+func (e *BetRangeError) Unwrap() error {
+	return e.Err
+}
+
+// SetBettingStructure задает ограничения ставок. Пустая строка - принятая для игры структура
+// (pot limit для омахи и no limit для остальных)
+func (cfg *TableConfig) SetBettingStructure(structure string, raiseCap int) error {
+	switch structure {
+	case "":
+		structure = cfg.Rules().DefaultBettingStructure()
+	case BettingNoLimit, BettingPotLimit, BettingFixedLimit:
+	default:
+		return ErrUnknownBettingStructure
+	}
+	if raiseCap <= 0 {
+		raiseCap = DefaultRaiseCap
+	}
+	cfg.BettingStructure = structure
+	cfg.RaiseCap = raiseCap
+	return nil
+}
+
+// betSize - минимальный bet на улице. В fixed limit это единственно возможный размер ставки и рейза:
+// малая ставка на префлопе и флопе, большая на терне и ривере
+func (t *PokerTable) betSize() int {
+	bigBlind := t.Config.SmallBlind * 2
+	if t.Config.BettingStructure == BettingFixedLimit && t.Meta.CurrentRound >= 2 {
+		return bigBlind * 2
+	}
+	return bigBlind
+}
+
+// potSize - все фишки в игре, включая ставки текущей улицы
+func (t *PokerTable) potSize() int {
+	pot := 0
+	for _, v := range t.contributors() {
+		pot += v.GetTotalBet()
+	}
+	return pot
+}
+
+// raiseRange возвращает границы, до которых игрок может поднять ставку по правилам стола
+func (t *PokerTable) raiseRange(p IPlayer) (int, int) {
+	minTo := t.Meta.CurrentBet + t.Meta.LastRaise
+	maxTo := p.GetLastBet() + p.GetBalance()
+	switch t.Config.BettingStructure {
+	case BettingPotLimit:
+		call := t.Meta.CurrentBet - p.GetLastBet()
+		maxTo = t.Meta.CurrentBet + t.potSize() + call
+	case BettingFixedLimit:
+		minTo = t.Meta.CurrentBet + t.betSize()
+		maxTo = minTo
+	}
+	return minTo, max(minTo, maxTo)
+}
+
+// checkRaise проверяет, что игрок может поднять ставку до amount
+func (t *PokerTable) checkRaise(p IPlayer, amount int, allIn bool) error {
+	if t.Config.BettingStructure == BettingFixedLimit && t.Meta.RaisesCount >= t.Config.RaiseCap {
+		return ErrRaiseCap
+	}
+	minTo, maxTo := t.raiseRange(p)
+	if amount > maxTo {
+		return &BetRangeError{Err: ErrRaiseTooBig, Min: minTo, Max: maxTo}
+	}
+	if amount < minTo && !allIn {
+		return &BetRangeError{Err: ErrCantRaise, Min: minTo, Max: maxTo}
+	}
+	return nil
+}
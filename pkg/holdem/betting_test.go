@@ -0,0 +1,81 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPotLimit(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	require.NoError(t, config.SetBettingStructure(BettingPotLimit, 0))
+	table := NewPokerTable(config)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+
+	// 150 в банке + 100 на колл: максимум 100 + 250
+	err := table.MakeMove(p2.GetId(), "raise", 400)
+	require.ErrorIs(t, err, ErrRaiseTooBig)
+	require.Equal(t, err, &BetRangeError{Err: ErrRaiseTooBig, Min: 200, Max: 350})
+	require.ErrorIs(t, table.MakeMove(p2.GetId(), "allin", 0), ErrRaiseTooBig)
+	require.NoError(t, table.MakeMove(p2.GetId(), "raise", 350))
+
+	// 500 в банке + 300 на колл: максимум 350 + 800
+	require.NoError(t, table.MakeMove(p3.GetId(), "raise", 1000))
+	require.NoError(t, table.MakeMove(p1.GetId(), "allin", 0))
+}
+
+func TestFixedLimit(t *testing.T) {
+	t.Run("fixed bet size", func(t *testing.T) {
+		config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+		require.NoError(t, config.SetBettingStructure(BettingFixedLimit, 0))
+		table := NewPokerTable(config)
+		p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+		p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+		p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+		table.AddPlayer(p1)
+		table.AddPlayer(p2)
+		table.AddPlayer(p3)
+		table.StartGame()
+		err := table.MakeMove(p2.GetId(), "raise", 300)
+		require.Equal(t, err, &BetRangeError{Err: ErrRaiseTooBig, Min: 200, Max: 200})
+		require.NoError(t, table.MakeMove(p2.GetId(), "raise", 200))
+		require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+		require.NoError(t, table.MakeMove(p1.GetId(), "call", 0))
+
+		// флоп - малая ставка
+		require.NoError(t, table.MakeMove(p3.GetId(), "bet", 100))
+		require.NoError(t, table.MakeMove(p1.GetId(), "call", 0))
+		require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+
+		// терн - большая ставка
+		require.Equal(t, table.Meta.CurrentRound, 2)
+		require.ErrorIs(t, table.MakeMove(p3.GetId(), "bet", 100), ErrCantRaise)
+		require.NoError(t, table.MakeMove(p3.GetId(), "bet", 200))
+	})
+
+	t.Run("raise cap", func(t *testing.T) {
+		config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+		require.NoError(t, config.SetBettingStructure(BettingFixedLimit, 0))
+		table := NewPokerTable(config)
+		p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+		p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+		p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+		table.AddPlayer(p1)
+		table.AddPlayer(p2)
+		table.AddPlayer(p3)
+		table.StartGame()
+		require.NoError(t, table.MakeMove(p2.GetId(), "raise", 200))
+		require.NoError(t, table.MakeMove(p3.GetId(), "raise", 300))
+		require.NoError(t, table.MakeMove(p1.GetId(), "raise", 400))
+		require.ErrorIs(t, table.MakeMove(p2.GetId(), "raise", 500), ErrRaiseCap)
+		require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+	})
+}
@@ -0,0 +1,115 @@
+package holdem
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrUnknownBlindStructure = errors.New("unknown blind structure")
+	ErrBadBlindLevel         = errors.New("small blind of each level must be positive and ante cant be negative")
+)
+
+const (
+	BlindStructureRegular = "regular"
+	BlindStructureTurbo   = "turbo"
+	BlindStructureHyper   = "hyper"
+	BlindStructureCustom  = "custom"
+)
+
+// BlindLevel
+// @Schema
+type BlindLevel struct {
+	SmallBlind int `json:"small_blind" example:"100"`
+	Ante       int `json:"ante" example:"0"`
+}
+
+// множители стартового малого блайнда в процентах. Анте растет в той же пропорции
+var blindStructurePresets = map[string][]int{
+	BlindStructureRegular: {100, 150, 200, 300, 400, 600, 800, 1000, 1500, 2000, 3000, 4000, 6000, 8000, 10000},
+	BlindStructureTurbo:   {100, 200, 300, 400, 600, 800, 1200, 1600, 2400, 3200, 4800, 6400, 9600},
+	BlindStructureHyper:   {100, 200, 400, 800, 1600, 3200, 6400, 12800},
+}
+
+// BuildBlindLevels строит уровни блайндов по пресету, отталкиваясь от стартовых малого блайнда и анте
+func BuildBlindLevels(structure string, smallBlind, ante int) ([]BlindLevel, error) {
+	multipliers, ok := blindStructurePresets[structure]
+	if !ok {
+		return nil, ErrUnknownBlindStructure
+	}
+	levels := make([]BlindLevel, 0, len(multipliers))
+	for _, m := range multipliers {
+		levels = append(levels, BlindLevel{
+			SmallBlind: smallBlind * m / 100,
+			Ante:       ante * m / 100,
+		})
+	}
+	return levels, nil
+}
+
+// SetBlindStructure задает расписание блайндов. Если переданы уровни, то расписание считается пользовательским,
+// иначе берется пресет. Без пресета sit n go играется по regular, а в кэш игре блайнды не растут
+func (cfg *TableConfig) SetBlindStructure(structure string, levels []BlindLevel) error {
+	if len(levels) == 0 && structure == "" && cfg.EnterAfterStart {
+		cfg.BlindStructure = ""
+		cfg.BlindLevels = nil
+		cfg.BlindLevel = 0
+		return nil
+	}
+	if len(levels) != 0 {
+		for _, l := range levels {
+			if l.SmallBlind <= 0 || l.Ante < 0 {
+				return ErrBadBlindLevel
+			}
+		}
+		cfg.BlindStructure = BlindStructureCustom
+		cfg.BlindLevels = levels
+	} else {
+		if structure == "" {
+			structure = BlindStructureRegular
+		}
+		built, err := BuildBlindLevels(structure, cfg.SmallBlind, cfg.Ante)
+		if err != nil {
+			return err
+		}
+		cfg.BlindStructure = structure
+		cfg.BlindLevels = built
+	}
+	cfg.BlindLevel = 0
+	cfg.SmallBlind = cfg.BlindLevels[0].SmallBlind
+	cfg.Ante = cfg.BlindLevels[0].Ante
+	return nil
+}
+
+// updateBlindLevel вызывается между раздачами и поднимает блайнды, если вышло время уровня
+func (t *PokerTable) updateBlindLevel() {
+	cfg := t.Config
+	if cfg.BlindIncreaseTime <= 0 || len(cfg.BlindLevels) == 0 {
+		return
+	}
+	raised := false
+	for cfg.BlindLevel < len(cfg.BlindLevels)-1 && !time.Now().Before(cfg.LastBlindIncrease.Add(cfg.BlindIncreaseTime)) {
+		cfg.BlindLevel++
+		cfg.LastBlindIncrease = cfg.LastBlindIncrease.Add(cfg.BlindIncreaseTime)
+		raised = true
+	}
+	if !raised {
+		return
+	}
+	level := cfg.BlindLevels[cfg.BlindLevel]
+	cfg.SmallBlind = level.SmallBlind
+	cfg.Ante = level.Ante
+
+	data := BlindLevelUp{
+		Level:      cfg.BlindLevel,
+		SmallBlind: level.SmallBlind,
+		BigBlind:   level.SmallBlind * 2,
+		Ante:       level.Ante,
+	}
+	if cfg.BlindLevel < len(cfg.BlindLevels)-1 {
+		next := cfg.BlindLevels[cfg.BlindLevel+1]
+		data.NextLevel = &next
+		data.TimeToNextLevel = time.Until(cfg.LastBlindIncrease.Add(cfg.BlindIncreaseTime)).Seconds()
+	}
+	t.notify(t.public(), EventBlindLevelUp, data)
+}
@@ -0,0 +1,103 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildBlindLevels(t *testing.T) {
+	cases := []struct {
+		TestCaseName string
+		Structure    string
+		SmallBlind   int
+		Ante         int
+		ExpectedLen  int
+		ExpectedLast BlindLevel
+		ExpectedErr  error
+	}{
+		{
+			TestCaseName: "regular",
+			Structure:    BlindStructureRegular,
+			SmallBlind:   10,
+			Ante:         2,
+			ExpectedLen:  15,
+			ExpectedLast: BlindLevel{SmallBlind: 1000, Ante: 200},
+		},
+		{
+			TestCaseName: "hyper",
+			Structure:    BlindStructureHyper,
+			SmallBlind:   50,
+			Ante:         0,
+			ExpectedLen:  8,
+			ExpectedLast: BlindLevel{SmallBlind: 6400, Ante: 0},
+		},
+		{
+			TestCaseName: "unknown",
+			Structure:    "slow",
+			SmallBlind:   50,
+			ExpectedErr:  ErrUnknownBlindStructure,
+		},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			levels, err := BuildBlindLevels(tCase.Structure, tCase.SmallBlind, tCase.Ante)
+			require.ErrorIs(t, err, tCase.ExpectedErr)
+			require.Len(t, levels, tCase.ExpectedLen)
+			if tCase.ExpectedLen != 0 {
+				require.Equal(t, levels[0], BlindLevel{SmallBlind: tCase.SmallBlind, Ante: tCase.Ante})
+				require.Equal(t, levels[len(levels)-1], tCase.ExpectedLast)
+			}
+		})
+	}
+}
+
+func TestBlindLevelUp(t *testing.T) {
+	config := NewTableConfig(time.Minute, 10, 2, 50, 0, 0, true, 1488)
+	require.NoError(t, config.SetBlindStructure("", []BlindLevel{{50, 0}, {100, 10}, {200, 25}}))
+	config.LastBlindIncrease = time.Now().Add(-90 * time.Second)
+	table := NewPokerTable(config)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.StartGame()
+
+	require.Equal(t, config.BlindStructure, BlindStructureCustom)
+	require.Equal(t, config.BlindLevel, 1)
+	require.Equal(t, config.SmallBlind, 100)
+	require.Equal(t, config.Ante, 10)
+	require.Equal(t, table.Meta.CurrentBet, 200)
+}
+
+func TestDefaultBlindStructure(t *testing.T) {
+	testCases := []struct {
+		name            string
+		enterAfterStart bool
+		structure       string
+		expected        string
+		levels          int
+	}{
+		{"cash game without structure", true, "", "", 0},
+		{"cash game with structure", true, BlindStructureTurbo, BlindStructureTurbo, 13},
+		{"sit n go without structure", false, "", BlindStructureRegular, 15},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := NewTableConfig(time.Minute, 10, 2, 50, 0, 0, tc.enterAfterStart, 1488)
+			require.NoError(t, config.SetBlindStructure(tc.structure, nil))
+			require.Equal(t, config.BlindStructure, tc.expected)
+			require.Len(t, config.BlindLevels, tc.levels)
+
+			// блайнды растут только по расписанию, а в sit n go уровни отсчитываются от первой раздачи
+			config.LastBlindIncrease = time.Now().Add(-90 * time.Second)
+			table := NewPokerTable(config)
+			table.AddPlayer(&Player{Id: uuid.New(), Balance: 1000})
+			table.AddPlayer(&Player{Id: uuid.New(), Balance: 1000})
+			require.NoError(t, table.StartGame())
+			require.Equal(t, config.SmallBlind != 50, tc.levels != 0 && tc.enterAfterStart)
+		})
+	}
+}
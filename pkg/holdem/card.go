@@ -58,3 +58,14 @@ func GetStandardDeck() []Card {
 	}
 	return standardDeck
 }
+
+// GetShortDeck - колода шорт дека из 36 карт, без двоек, троек, четверок и пятерок
+func GetShortDeck() []Card {
+	shortDeck := make([]Card, 0, 36)
+	for _, c := range GetStandardDeck() {
+		if c.Value >= 6 {
+			shortDeck = append(shortDeck, c)
+		}
+	}
+	return shortDeck
+}
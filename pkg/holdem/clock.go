@@ -0,0 +1,91 @@
+package holdem
+
+import (
+	"time"
+)
+
+// refillTimeBanks восполняет банк времени всех игроков перед новой раздачей
+func (t *PokerTable) refillTimeBanks() {
+	for k := range t.Meta.Players {
+		t.Meta.TimeBanks[k] = t.Config.TimeBank
+	}
+}
+
+// startClock запускает таймер хода для игрока и рассылает его всем игрокам стола
+func (t *PokerTable) startClock(playerId string) {
+	t.stopClock()
+	if t.Config.MoveTimeout <= 0 {
+		return
+	}
+	t.Meta.TurnId++
+	turnId := t.Meta.TurnId
+	t.Meta.TurnDeadline = time.Now().Add(t.Config.MoveTimeout)
+	t.Meta.TimeBankStarted = time.Time{}
+	t.notify(t.public(), EventActionClock, t.clockInfo(playerId))
+	t.clock = time.AfterFunc(t.Config.MoveTimeout, func() { t.onClockExpired(playerId, turnId) })
+}
+
+func (t *PokerTable) stopClock() {
+	if t.clock != nil {
+		t.clock.Stop()
+		t.clock = nil
+	}
+	t.Meta.TurnDeadline = time.Time{}
+	t.Meta.TimeBankStarted = time.Time{}
+}
+
+func (t *PokerTable) clockInfo(playerId string) ActionClock {
+	return ActionClock{
+		PlayerId: playerId,
+		Deadline: t.Meta.TurnDeadline,
+		Seconds:  time.Until(t.Meta.TurnDeadline).Seconds(),
+		TimeBank: t.Meta.TimeBanks[playerId].Seconds(),
+	}
+}
+
+// onClockExpired срабатывает по таймеру. Сначала игрок переходит на банк времени,
+// а когда заканчивается и он, за игрока делается check или fold
+func (t *PokerTable) onClockExpired(playerId string, turnId int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.Meta.GameStarted || t.Meta.TurnId != turnId {
+		return
+	}
+	if _, ok := t.Meta.Players[playerId]; !ok { // игрок успел выйти из-за стола
+		return
+	}
+	bank := t.Meta.TimeBanks[playerId]
+	if t.Meta.TimeBankStarted.IsZero() && bank > 0 {
+		t.Meta.TimeBankStarted = time.Now()
+		t.Meta.TurnDeadline = t.Meta.TimeBankStarted.Add(bank)
+		t.notify(t.public(), EventTimeBank, t.clockInfo(playerId))
+		t.clock = time.AfterFunc(bank, func() { t.onClockExpired(playerId, turnId) })
+		return
+	}
+	t.clock = nil
+	t.Meta.TimeBanks[playerId] = 0
+	t.Meta.TimeBankStarted = time.Time{}
+
+	t.timeoutMove(playerId)
+}
+
+// timeoutMove делает за игрока check, а если это невозможно - fold
+func (t *PokerTable) timeoutMove(playerId string) {
+	action := "fold"
+	if t.canCheck(playerId) {
+		action = "check"
+	}
+	t.notify(t.public(), EventTimeout, PlayerTimeout{PlayerId: playerId, Action: action})
+	t.makeMove(playerId, action, 0)
+	t.countTimeout(playerId)
+}
+
+// chargeTimeBank списывает с банка времени то, что игрок успел потратить
+func (t *PokerTable) chargeTimeBank(playerId string) {
+	if t.Meta.TimeBankStarted.IsZero() {
+		return
+	}
+	used := time.Since(t.Meta.TimeBankStarted)
+	t.Meta.TimeBanks[playerId] = max(t.Meta.TimeBanks[playerId]-used, 0)
+	t.Meta.TimeBankStarted = time.Time{}
+}
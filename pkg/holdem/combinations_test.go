@@ -157,6 +157,10 @@ func TestEvaluateHandGood(t *testing.T) {
 				Rank: Straight,
 				CompareCards: []Card{
 					Card{Suit: "Diamonds", Value: 5},
+					Card{Suit: "Clubs", Value: 4},
+					Card{Suit: "Spades", Value: 3},
+					Card{Suit: "Diamonds", Value: 2},
+					Card{Suit: "Diamonds", Value: 14},
 				},
 			},
 		},
@@ -178,6 +182,10 @@ func TestEvaluateHandGood(t *testing.T) {
 				Rank: Straight,
 				CompareCards: []Card{
 					Card{Suit: "Spades", Value: 7},
+					Card{Suit: "Spades", Value: 6},
+					Card{Suit: "Diamonds", Value: 5},
+					Card{Suit: "Clubs", Value: 4},
+					Card{Suit: "Spades", Value: 3},
 				},
 			},
 		},
@@ -199,6 +207,10 @@ func TestEvaluateHandGood(t *testing.T) {
 				Rank: Straight,
 				CompareCards: []Card{
 					Card{Suit: "Diamonds", Value: 14},
+					Card{Suit: "Spades", Value: 13},
+					Card{Suit: "Diamonds", Value: 12},
+					Card{Suit: "Clubs", Value: 11},
+					Card{Suit: "Diamonds", Value: 10},
 				},
 			},
 		},
@@ -213,13 +225,17 @@ func TestEvaluateHandGood(t *testing.T) {
 				Card{Suit: "Diamonds", Value: 12},
 				Card{Suit: "Diamonds", Value: 8},
 				Card{Suit: "Diamonds", Value: 10},
-				Card{Suit: "Diamonds", Value: 6},
+				Card{Suit: "Diamonds", Value: 2},
 				Card{Suit: "Spades", Value: 7},
 			},
 			ExpectedCombination: Combination{
 				Rank: Flush,
 				CompareCards: []Card{
 					Card{Suit: "Diamonds", Value: 12},
+					Card{Suit: "Diamonds", Value: 10},
+					Card{Suit: "Diamonds", Value: 8},
+					Card{Suit: "Diamonds", Value: 6},
+					Card{Suit: "Diamonds", Value: 2},
 				},
 			},
 		},
@@ -316,6 +332,10 @@ func TestEvaluateHandGood(t *testing.T) {
 				Rank: StraightFlush,
 				CompareCards: []Card{
 					Card{Suit: "Diamonds", Value: 5},
+					Card{Suit: "Diamonds", Value: 4},
+					Card{Suit: "Diamonds", Value: 3},
+					Card{Suit: "Diamonds", Value: 2},
+					Card{Suit: "Diamonds", Value: 14},
 				},
 			},
 		},
@@ -337,6 +357,10 @@ func TestEvaluateHandGood(t *testing.T) {
 				Rank: StraightFlush,
 				CompareCards: []Card{
 					Card{Suit: "Diamonds", Value: 7},
+					Card{Suit: "Diamonds", Value: 6},
+					Card{Suit: "Diamonds", Value: 5},
+					Card{Suit: "Diamonds", Value: 4},
+					Card{Suit: "Diamonds", Value: 3},
 				},
 			},
 		},
@@ -358,6 +382,10 @@ func TestEvaluateHandGood(t *testing.T) {
 				Rank: StraightFlush,
 				CompareCards: []Card{
 					Card{Suit: "Spades", Value: 13},
+					Card{Suit: "Spades", Value: 12},
+					Card{Suit: "Spades", Value: 11},
+					Card{Suit: "Spades", Value: 10},
+					Card{Suit: "Spades", Value: 9},
 				},
 			},
 		},
@@ -379,6 +407,10 @@ func TestEvaluateHandGood(t *testing.T) {
 				Rank: RoyalFlush,
 				CompareCards: []Card{
 					Card{Suit: "Hearts", Value: 14},
+					Card{Suit: "Hearts", Value: 13},
+					Card{Suit: "Hearts", Value: 12},
+					Card{Suit: "Hearts", Value: 11},
+					Card{Suit: "Hearts", Value: 10},
 				},
 			},
 		},
@@ -387,7 +419,8 @@ func TestEvaluateHandGood(t *testing.T) {
 	for _, tCase := range cases {
 		t.Run(tCase.TestCaseName, func(t *testing.T) {
 			comb := EvaluateHand(tCase.PlayerHand, tCase.CommunityCards)
-			require.Equal(t, comb, tCase.ExpectedCombination)
+			require.Equal(t, comb.Rank, tCase.ExpectedCombination.Rank)
+			require.Equal(t, comb.CompareCards, tCase.ExpectedCombination.CompareCards)
 		})
 	}
 }
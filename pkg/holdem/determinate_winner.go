@@ -10,7 +10,15 @@ var (
 	ErrNotEnoughCardsInHand    = errors.New("len of player cards must be 2") //TODO add
 )
 
+// HandEvaluator находит лучшую комбинацию из карт игрока и общих карт
+type HandEvaluator func(playerHand []Card, communityCards []Card) Combination
+
 func DeterminateWinner(communityCards []Card, players map[string]IPlayer) ([]string, error) {
+	return DeterminateWinnerBy(EvaluateHand, communityCards, players)
+}
+
+// DeterminateWinnerBy определяет победителей, оценивая руки переданным способом (холдем, омаха)
+func DeterminateWinnerBy(evaluate HandEvaluator, communityCards []Card, players map[string]IPlayer) ([]string, error) {
 	if len(players) == 0 {
 		return []string{}, ErrEmptyPlayersMap
 	}
@@ -27,12 +35,11 @@ func DeterminateWinner(communityCards []Card, players map[string]IPlayer) ([]str
 		if player.GetFold() {
 			continue
 		}
-		combination := EvaluateHand(hand.Cards[:], communityCards)
-		if combination.Rank > bestCombination.Rank ||
-			(combination.Rank == bestCombination.Rank && compareCards(combination.CompareCards, bestCombination.CompareCards) > 0) {
+		combination := evaluate(hand.Cards, communityCards)
+		if cmp := CompareCombinations(combination, bestCombination); cmp > 0 || bestCombination.Rank == 0 {
 			bestPlayers = append(bestPlayers[:0], id)
 			bestCombination = combination
-		} else if combination.Rank == bestCombination.Rank && compareCards(combination.CompareCards, bestCombination.CompareCards) == 0 {
+		} else if cmp == 0 {
 			bestPlayers = append(bestPlayers, id)
 		}
 	}
@@ -139,9 +139,9 @@ func TestDeterminateWinnerGood(t *testing.T) {
 				{Suit: "Hearts", Value: 4}, {Suit: "Clubs", Value: 6},
 			},
 			Players: map[string]IPlayer{
-				"first":  &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 12}}}},
-				"second": &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 14}, {Suit: "Spades", Value: 14}}}},
-				"third":  &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 4}}}},
+				"first":  &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 12}}}},
+				"second": &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 14}, {Suit: "Spades", Value: 14}}}},
+				"third":  &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 4}}}},
 			},
 			Expected: []string{"third"},
 		},
@@ -152,8 +152,8 @@ func TestDeterminateWinnerGood(t *testing.T) {
 				{Suit: "Hearts", Value: 7}, {Suit: "Clubs", Value: 6},
 			},
 			Players: map[string]IPlayer{
-				"first":  &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 12}}}},
-				"second": &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 10}}}},
+				"first":  &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 12}}}},
+				"second": &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 10}}}},
 			},
 			Expected:    []string{"first"},
 			ExpectedErr: nil,
@@ -165,8 +165,8 @@ func TestDeterminateWinnerGood(t *testing.T) {
 				{Suit: "Hearts", Value: 7}, {Suit: "Clubs", Value: 6},
 			},
 			Players: map[string]IPlayer{
-				"first":  &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 12}}}, IsFold: true},
-				"second": &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 9}}}},
+				"first":  &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 12}}}, IsFold: true},
+				"second": &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 9}}}},
 			},
 			Expected:    []string{"second"},
 			ExpectedErr: nil,
@@ -178,23 +178,23 @@ func TestDeterminateWinnerGood(t *testing.T) {
 				{Suit: "Hearts", Value: 11}, {Suit: "Clubs", Value: 10},
 			},
 			Players: map[string]IPlayer{
-				"first":  &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 12}}}},
-				"second": &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 9}}}},
+				"first":  &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 12}}}},
+				"second": &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 9}}}},
 			},
 			Expected:    []string{"first", "second"},
 			ExpectedErr: nil,
 		},
 		{
-			TestCaseName: "Same cards in hands",
+			TestCaseName: "Flushes are compared by all five cards",
 			CommunityCards: []Card{
 				{Suit: "Spades", Value: 14}, {Suit: "Spades", Value: 13}, {Suit: "Spades", Value: 12},
 				{Suit: "Hearts", Value: 11}, {Suit: "Clubs", Value: 10},
 			},
 			Players: map[string]IPlayer{
-				"first":  &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 5}, {Suit: "Spades", Value: 4}}}},
-				"second": &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 9}}}},
+				"first":  &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 5}, {Suit: "Spades", Value: 4}}}},
+				"second": &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 9}}}},
 			},
-			Expected:    []string{"first", "second"},
+			Expected:    []string{"second"},
 			ExpectedErr: nil,
 		},
 		{
@@ -203,8 +203,8 @@ func TestDeterminateWinnerGood(t *testing.T) {
 				{Suit: "Hearts", Value: 10}, {Suit: "Clubs", Value: 9}, {Suit: "Diamonds", Value: 8},
 			},
 			Players: map[string]IPlayer{
-				"first":  &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 12}}}},
-				"second": &Player{Hand: Hand{[2]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 9}}}},
+				"first":  &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 11}, {Suit: "Spades", Value: 12}}}},
+				"second": &Player{Hand: Hand{[]Card{{Suit: "Spades", Value: 10}, {Suit: "Spades", Value: 9}}}},
 			},
 			Expected:    []string{},
 			ExpectedErr: ErrNotEnoughCommunityCards,
@@ -0,0 +1,481 @@
+package holdem
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBadCard           = errors.New("bad card, expected rank 2-9, T, J, Q, K, A and suit s, h, d, c (for example As)")
+	ErrBadRange          = errors.New("bad hand range")
+	ErrEmptyRange        = errors.New("hand range has no combos with remaining cards")
+	ErrDuplicateCard     = errors.New("card is used twice")
+	ErrNotEnoughHands    = errors.New("at least two hands are required")
+	ErrTooManyBoardCards = errors.New("board cant have more than 5 cards")
+	ErrTooManyHands      = errors.New("too many hands")
+	ErrTooManyIterations = errors.New("too many iterations")
+)
+
+const (
+	DefaultEquityIterations = 100000
+	MaxEquityIterations     = 1000000
+	MaxEquityHands          = 10
+	// ExhaustiveEquityLimit - если раздач меньше, шансы считаются полным перебором, иначе методом Монте-Карло
+	ExhaustiveEquityLimit = 300000
+)
+
+var (
+	rankFromChar = map[byte]int{
+		'2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
+		'T': 10, 'J': 11, 'Q': 12, 'K': 13, 'A': 14,
+	}
+	suitFromChar = map[byte]string{'s': "Spades", 'h': "Hearts", 'd': "Diamonds", 'c': "Clubs"}
+	suits        = []string{"Spades", "Hearts", "Diamonds", "Clubs"}
+)
+
+// EquityRequest
+// @Schema
+type EquityRequest struct {
+	Hands      []string `json:"hands" example:"AsAh,KK+"`    // конкретные карты (AsKd) или диапазон (QQ+, AKs, ATo+, 22-55), через запятую
+	Board      string   `json:"board" example:"2c7d9s"`      // 0-5 карт
+	Dead       string   `json:"dead" example:""`             // вышедшие из игры карты
+	Iterations int      `json:"iterations" example:"100000"` // не больше MaxEquityIterations
+	Seed       int64    `json:"-"`
+}
+
+// Equity - шансы одной руки в процентах
+// @Schema
+type Equity struct {
+	Hand   string  `json:"hand"`
+	Win    float64 `json:"win"`
+	Tie    float64 `json:"tie"`
+	Equity float64 `json:"equity"`
+}
+
+// EquityResult
+// @Schema
+type EquityResult struct {
+	Players    []Equity `json:"players"`
+	Exhaustive bool     `json:"exhaustive"` // true - полный перебор, false - Монте-Карло
+	Deals      int      `json:"deals"`      // сколько раздач посчитано
+}
+
+// ParseCard разбирает карту в формате As, Td, 10h
+func ParseCard(s string) (Card, error) {
+	if len(s) == 3 && s[:2] == "10" {
+		s = "T" + s[2:]
+	}
+	if len(s) != 2 {
+		return Card{}, ErrBadCard
+	}
+	value, ok := rankFromChar[upper(s[0])]
+	if !ok {
+		return Card{}, ErrBadCard
+	}
+	suit, ok := suitFromChar[lower(s[1])]
+	if !ok {
+		return Card{}, ErrBadCard
+	}
+	return Card{Suit: suit, Value: value}, nil
+}
+
+// ParseCards разбирает карты, записанные подряд (AsKd) или через пробел и запятую
+func ParseCards(s string) ([]Card, error) {
+	s = strings.NewReplacer(" ", "", ",", "").Replace(s)
+	cards := make([]Card, 0, len(s)/2)
+	for i := 0; i < len(s); {
+		size := 2
+		if strings.HasPrefix(s[i:], "10") {
+			size = 3
+		}
+		if i+size > len(s) {
+			return nil, ErrBadCard
+		}
+		c, err := ParseCard(s[i : i+size])
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+		i += size
+	}
+	return cards, nil
+}
+
+// ParseRange разбирает диапазон рук: конкретные карты (AsKd), пары (QQ, QQ+, 22-55),
+// одномастные и разномастные руки (AKs, AKo, AK, ATs+). Части диапазона перечисляются через запятую
+func ParseRange(s string) ([][]Card, error) {
+	combos := make([][]Card, 0)
+	seen := make(map[[2]Card]bool)
+	for _, token := range strings.Split(s, ",") {
+		token = strings.TrimSpace(token)
+		hands, err := parseRangeToken(token)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hands {
+			key := [2]Card{h[0], h[1]}
+			if seen[key] || seen[[2]Card{h[1], h[0]}] {
+				continue
+			}
+			seen[key] = true
+			combos = append(combos, h)
+		}
+	}
+	if len(combos) == 0 {
+		return nil, ErrBadRange
+	}
+	return combos, nil
+}
+
+func parseRangeToken(token string) ([][]Card, error) {
+	if cards, err := ParseCards(token); err == nil && len(cards) == 2 {
+		if cards[0] == cards[1] {
+			return nil, ErrDuplicateCard
+		}
+		return [][]Card{cards}, nil
+	}
+	if len(token) < 2 {
+		return nil, ErrBadRange
+	}
+	high, ok1 := rankFromChar[upper(token[0])]
+	low, ok2 := rankFromChar[upper(token[1])]
+	if !ok1 || !ok2 {
+		return nil, ErrBadRange
+	}
+	rest := token[2:]
+
+	if high == low {
+		// пары: QQ, QQ+, 22-55
+		from, to := high, high
+		switch {
+		case rest == "":
+		case rest == "+":
+			to = 14
+		case len(rest) == 3 && rest[0] == '-' && rest[1] == rest[2]:
+			end, ok := rankFromChar[upper(rest[1])]
+			if !ok {
+				return nil, ErrBadRange
+			}
+			from, to = min(high, end), max(high, end)
+		default:
+			return nil, ErrBadRange
+		}
+		hands := make([][]Card, 0)
+		for v := from; v <= to; v++ {
+			hands = append(hands, rankCombos(v, v, true, true)...)
+		}
+		return hands, nil
+	}
+
+	if high < low {
+		high, low = low, high
+	}
+	suited, offsuit := true, true
+	if strings.HasPrefix(rest, "s") {
+		offsuit = false
+		rest = rest[1:]
+	} else if strings.HasPrefix(rest, "o") {
+		suited = false
+		rest = rest[1:]
+	}
+	to := low
+	switch rest {
+	case "":
+	case "+":
+		to = high - 1
+	default:
+		return nil, ErrBadRange
+	}
+	hands := make([][]Card, 0)
+	for v := low; v <= to; v++ {
+		hands = append(hands, rankCombos(high, v, suited, offsuit)...)
+	}
+	return hands, nil
+}
+
+// rankCombos - все сочетания мастей для двух достоинств
+func rankCombos(high, low int, suited, offsuit bool) [][]Card {
+	hands := make([][]Card, 0, 16)
+	for i, s1 := range suits {
+		for j, s2 := range suits {
+			if high == low && j <= i {
+				continue
+			}
+			if (s1 == s2 && !suited) || (s1 != s2 && !offsuit) {
+				continue
+			}
+			hands = append(hands, []Card{{Suit: s1, Value: high}, {Suit: s2, Value: low}})
+		}
+	}
+	return hands
+}
+
+func upper(b byte) byte {
+	if b >= 'a' && b <= 'z' {
+		return b - 'a' + 'A'
+	}
+	return b
+}
+
+func lower(b byte) byte {
+	if b >= 'A' && b <= 'Z' {
+		return b - 'A' + 'a'
+	}
+	return b
+}
+
+func cardBit(c Card) uint64 {
+	return 1 << encodeCard(c).index()
+}
+
+// index - номер карты от 0 до 51
+func (c cardCode) index() int {
+	suit := 0
+	switch c >> 12 & 0xF {
+	case 2:
+		suit = 1
+	case 4:
+		suit = 2
+	case 8:
+		suit = 3
+	}
+	return int(c>>8&0xF)*4 + suit
+}
+
+// CalculateEquity считает шансы рук на победу. Руки можно задать диапазонами, тогда каждое сочетание из диапазона
+// считается равновероятным. Если раздач немного, они перебираются полностью, иначе берется Iterations случайных
+func CalculateEquity(req EquityRequest) (EquityResult, error) {
+	if len(req.Hands) < 2 {
+		return EquityResult{}, ErrNotEnoughHands
+	}
+	if len(req.Hands) > MaxEquityHands {
+		return EquityResult{}, ErrTooManyHands
+	}
+	if req.Iterations > MaxEquityIterations {
+		return EquityResult{}, ErrTooManyIterations
+	}
+	board, err := ParseCards(req.Board)
+	if err != nil {
+		return EquityResult{}, err
+	}
+	if len(board) > 5 {
+		return EquityResult{}, ErrTooManyBoardCards
+	}
+	dead, err := ParseCards(req.Dead)
+	if err != nil {
+		return EquityResult{}, err
+	}
+	var used uint64
+	for _, c := range append(append([]Card{}, board...), dead...) {
+		if used&cardBit(c) != 0 {
+			return EquityResult{}, ErrDuplicateCard
+		}
+		used |= cardBit(c)
+	}
+
+	ranges := make([][][]Card, len(req.Hands))
+	for i, h := range req.Hands {
+		combos, err := ParseRange(h)
+		if err != nil {
+			return EquityResult{}, err
+		}
+		ranges[i] = make([][]Card, 0, len(combos))
+		for _, combo := range combos {
+			if used&(cardBit(combo[0])|cardBit(combo[1])) == 0 {
+				ranges[i] = append(ranges[i], combo)
+			}
+		}
+		if len(ranges[i]) == 0 {
+			return EquityResult{}, ErrEmptyRange
+		}
+	}
+
+	deck := make([]Card, 0, 52)
+	for _, c := range GetStandardDeck() {
+		if used&cardBit(c) == 0 {
+			deck = append(deck, c)
+		}
+	}
+
+	need := 5 - len(board)
+	if len(deck)-2*len(ranges) < need {
+		return EquityResult{}, ErrNotEnoughCards
+	}
+	calc := newEquityCalculator(len(ranges), board)
+	if estimateDeals(ranges, len(deck)-2*len(ranges), need) <= ExhaustiveEquityLimit {
+		calc.exhaustive(ranges, deck, need)
+	} else {
+		iterations := req.Iterations
+		if iterations <= 0 {
+			iterations = DefaultEquityIterations
+		}
+		seed := req.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		calc.monteCarlo(ranges, deck, need, iterations, rand.New(rand.NewSource(seed)))
+	}
+	if calc.deals == 0 {
+		return EquityResult{}, ErrEmptyRange
+	}
+	return calc.result(req.Hands), nil
+}
+
+// estimateDeals - верхняя оценка числа раздач при полном переборе
+func estimateDeals(ranges [][][]Card, rest, need int) int {
+	deals := 1
+	for _, r := range ranges {
+		deals *= len(r)
+		if deals > ExhaustiveEquityLimit {
+			return deals
+		}
+	}
+	// сочетания из rest по need
+	boards := 1
+	for i := 0; i < need; i++ {
+		boards = boards * (rest - i) / (i + 1)
+	}
+	if boards > 0 && deals > ExhaustiveEquityLimit/boards {
+		return ExhaustiveEquityLimit + 1
+	}
+	return deals * boards
+}
+
+type equityCalculator struct {
+	board   []Card
+	hands   [][]Card
+	wins    []float64
+	ties    []float64
+	equity  []float64
+	ranks   []HandRank
+	cards   []Card
+	deals   int
+	isExact bool
+}
+
+func newEquityCalculator(players int, board []Card) *equityCalculator {
+	return &equityCalculator{
+		board:  append(make([]Card, 0, 5), board...),
+		hands:  make([][]Card, players),
+		wins:   make([]float64, players),
+		ties:   make([]float64, players),
+		equity: make([]float64, players),
+		ranks:  make([]HandRank, players),
+		cards:  make([]Card, 7),
+	}
+}
+
+// showdown оценивает руки на полной доске и начисляет выигрыш
+func (e *equityCalculator) showdown(board []Card) {
+	var best HandRank
+	winners := 0
+	copy(e.cards[2:], board)
+	for i, h := range e.hands {
+		e.cards[0], e.cards[1] = h[0], h[1]
+		e.ranks[i] = standardLookup.Rank(e.cards)
+		if e.ranks[i] > best {
+			best, winners = e.ranks[i], 1
+		} else if e.ranks[i] == best {
+			winners++
+		}
+	}
+	for i, r := range e.ranks {
+		if r != best {
+			continue
+		}
+		if winners == 1 {
+			e.wins[i]++
+		} else {
+			e.ties[i]++
+		}
+		e.equity[i] += 1 / float64(winners)
+	}
+	e.deals++
+}
+
+func (e *equityCalculator) exhaustive(ranges [][][]Card, deck []Card, need int) {
+	e.isExact = true
+	var deal func(player int, used uint64)
+	deal = func(player int, used uint64) {
+		if player == len(ranges) {
+			rest := make([]Card, 0, len(deck))
+			for _, c := range deck {
+				if used&cardBit(c) == 0 {
+					rest = append(rest, c)
+				}
+			}
+			e.boards(rest, need, 0, e.board)
+			return
+		}
+		for _, combo := range ranges[player] {
+			bits := cardBit(combo[0]) | cardBit(combo[1])
+			if used&bits != 0 {
+				continue
+			}
+			e.hands[player] = combo
+			deal(player+1, used|bits)
+		}
+	}
+	deal(0, 0)
+}
+
+// boards перебирает все способы доложить need карт на доску
+func (e *equityCalculator) boards(rest []Card, need, from int, board []Card) {
+	if need == 0 {
+		e.showdown(board)
+		return
+	}
+	for i := from; i <= len(rest)-need; i++ {
+		e.boards(rest, need-1, i+1, append(board, rest[i]))
+	}
+}
+
+func (e *equityCalculator) monteCarlo(ranges [][][]Card, deck []Card, need, iterations int, r *rand.Rand) {
+	rest := make([]Card, 0, len(deck))
+	board := make([]Card, 0, 5)
+	for it := 0; it < iterations; it++ {
+		var used uint64
+		ok := true
+		for i, rng := range ranges {
+			combo := rng[r.Intn(len(rng))]
+			bits := cardBit(combo[0]) | cardBit(combo[1])
+			if used&bits != 0 {
+				ok = false
+				break
+			}
+			e.hands[i] = combo
+			used |= bits
+		}
+		// сочетания из диапазонов пересеклись, раздача не засчитывается
+		if !ok {
+			continue
+		}
+		rest = rest[:0]
+		for _, c := range deck {
+			if used&cardBit(c) == 0 {
+				rest = append(rest, c)
+			}
+		}
+		for i := 0; i < need; i++ {
+			j := i + r.Intn(len(rest)-i)
+			rest[i], rest[j] = rest[j], rest[i]
+		}
+		board = append(append(board[:0], e.board...), rest[:need]...)
+		e.showdown(board)
+	}
+}
+
+func (e *equityCalculator) result(hands []string) EquityResult {
+	res := EquityResult{Players: make([]Equity, len(hands)), Exhaustive: e.isExact, Deals: e.deals}
+	for i, h := range hands {
+		res.Players[i] = Equity{
+			Hand:   h,
+			Win:    e.wins[i] * 100 / float64(e.deals),
+			Tie:    e.ties[i] * 100 / float64(e.deals),
+			Equity: e.equity[i] * 100 / float64(e.deals),
+		}
+	}
+	return res
+}
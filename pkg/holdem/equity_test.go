@@ -0,0 +1,127 @@
+package holdem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		TestCaseName string
+		Range        string
+		ExpectedLen  int
+		ExpectedErr  error
+	}{
+		{TestCaseName: "Exact cards", Range: "AsKd", ExpectedLen: 1},
+		{TestCaseName: "Ten as 10", Range: "10h10c", ExpectedLen: 1},
+		{TestCaseName: "Pair", Range: "AA", ExpectedLen: 6},
+		{TestCaseName: "Pairs plus", Range: "QQ+", ExpectedLen: 18},
+		{TestCaseName: "Pairs dash", Range: "22-55", ExpectedLen: 24},
+		{TestCaseName: "Suited", Range: "AKs", ExpectedLen: 4},
+		{TestCaseName: "Offsuit", Range: "AKo", ExpectedLen: 12},
+		{TestCaseName: "Any suits", Range: "KA", ExpectedLen: 16},
+		{TestCaseName: "Suited plus", Range: "ATs+", ExpectedLen: 16},
+		{TestCaseName: "Several parts without duplicates", Range: "AKs, AK, QQ", ExpectedLen: 22},
+		{TestCaseName: "Same card twice", Range: "AsAs", ExpectedErr: ErrDuplicateCard},
+		{TestCaseName: "Bad rank", Range: "AX", ExpectedErr: ErrBadRange},
+		{TestCaseName: "Bad suffix", Range: "AKx", ExpectedErr: ErrBadRange},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			combos, err := ParseRange(tCase.Range)
+			require.ErrorIs(t, err, tCase.ExpectedErr)
+			require.Len(t, combos, tCase.ExpectedLen)
+		})
+	}
+}
+
+func TestCalculateEquity(t *testing.T) {
+	cases := []struct {
+		TestCaseName       string
+		Request            EquityRequest
+		ExpectedExhaustive bool
+		ExpectedEquity     []float64
+		Delta              float64
+		ExpectedErr        error
+	}{
+		{
+			TestCaseName:       "Aces against kings preflop",
+			Request:            EquityRequest{Hands: []string{"AsAh", "KsKh"}, Iterations: 50000, Seed: 1488},
+			ExpectedExhaustive: false,
+			ExpectedEquity:     []float64{82.6, 17.4},
+			Delta:              1,
+		},
+		{
+			TestCaseName:       "Flush draw on the turn",
+			Request:            EquityRequest{Hands: []string{"AsAh", "KdQd"}, Board: "2d7d9c3s"},
+			ExpectedExhaustive: true,
+			// 9 бубен из 44 оставшихся карт
+			ExpectedEquity: []float64{100 - 900.0/44, 900.0 / 44},
+			Delta:          0.001,
+		},
+		{
+			TestCaseName:       "Board plays",
+			Request:            EquityRequest{Hands: []string{"2s3h", "4c5d", "2c3d"}, Board: "AsKsQsJsTs"},
+			ExpectedExhaustive: true,
+			ExpectedEquity:     []float64{100.0 / 3, 100.0 / 3, 100.0 / 3},
+			Delta:              0.001,
+		},
+		{
+			TestCaseName:       "Dead cards are not dealt",
+			Request:            EquityRequest{Hands: []string{"AsAh", "KdQd"}, Board: "2d7d9c3s", Dead: "3d4d5d6d8d"},
+			ExpectedExhaustive: true,
+			ExpectedEquity:     []float64{100 - 400.0/39, 400.0 / 39},
+			Delta:              0.001,
+		},
+		{
+			TestCaseName: "One hand",
+			Request:      EquityRequest{Hands: []string{"AsAh"}},
+			ExpectedErr:  ErrNotEnoughHands,
+		},
+		{
+			TestCaseName: "Too many hands",
+			Request:      EquityRequest{Hands: []string{"AA", "KK", "QQ", "JJ", "TT", "99", "88", "77", "66", "55", "44"}},
+			ExpectedErr:  ErrTooManyHands,
+		},
+		{
+			TestCaseName: "Too many iterations",
+			Request:      EquityRequest{Hands: []string{"AsAh", "KsKh"}, Iterations: MaxEquityIterations + 1},
+			ExpectedErr:  ErrTooManyIterations,
+		},
+		{
+			TestCaseName: "Card on board and in hand",
+			Request:      EquityRequest{Hands: []string{"AsAh", "KsKh"}, Board: "As2c3c"},
+			ExpectedErr:  ErrEmptyRange,
+		},
+		{
+			TestCaseName: "Bad board",
+			Request:      EquityRequest{Hands: []string{"AsAh", "KsKh"}, Board: "2c3x"},
+			ExpectedErr:  ErrBadCard,
+		},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			res, err := CalculateEquity(tCase.Request)
+			require.ErrorIs(t, err, tCase.ExpectedErr)
+			if err != nil {
+				return
+			}
+			require.Equal(t, res.Exhaustive, tCase.ExpectedExhaustive)
+			require.Len(t, res.Players, len(tCase.ExpectedEquity))
+			for i, e := range tCase.ExpectedEquity {
+				require.InDelta(t, res.Players[i].Equity, e, tCase.Delta)
+			}
+		})
+	}
+}
+
+func TestEquityRanges(t *testing.T) {
+	res, err := CalculateEquity(EquityRequest{Hands: []string{"QQ+", "AKs"}, Board: "2c7d9h"})
+	require.NoError(t, err)
+	require.True(t, res.Exhaustive)
+	// QQ+ без общих с AKs карт: 6 QQ, 3 KK и 3 AA на каждое из 4 сочетаний AKs
+	require.Equal(t, res.Deals, 4*12*990)
+	require.Greater(t, res.Players[0].Equity, 80.0)
+	require.InDelta(t, res.Players[0].Equity+res.Players[1].Equity, 100, 0.001)
+}
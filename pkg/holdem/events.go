@@ -0,0 +1,322 @@
+package holdem
+
+import (
+	"errors"
+	"time"
+)
+
+// EventSchemaVersion - версия схемы событий. Увеличивается, когда поля событий меняются несовместимо
+const EventSchemaVersion = 1
+
+// типы событий стола (ObserverMessage.EventType)
+const (
+	EventPlayerEnter    = "player_enter"
+	EventGameStarted    = "game_started"
+	EventSeats          = "seats"
+	EventReplayState    = "replay_state"
+	EventPlayersStats   = "players_stats"
+	EventNewRound       = "new_round"
+	EventGetCards       = "get_cards"
+	EventCommunityCards = "community_cards"
+	EventStopGame       = "stop_game"
+	EventWinAll         = "win_all"
+	EventWinPot         = "win_pot"
+	EventReturnBet      = "return_bet"
+	EventCantAnte       = "cant_ante"
+	EventGetAnte        = "get_ante"
+	EventSmallBlind     = "small_blind"
+	EventBigBlind       = "big_blind"
+	EventButtonBlind    = "button_blind"
+	EventNextMove       = "next_move"
+	EventDealer         = "dealer"
+	EventBadMove        = "bad_move"
+	EventCanDo          = "can_do"
+	EventDo             = "do"
+	EventBlindLevelUp   = "blind_level_up"
+	EventActionClock    = "action_clock"
+	EventTimeBank       = "time_bank"
+	EventTimeout        = "timeout"
+	EventShuffleCommit  = "shuffle_commit"
+	EventShuffleReveal  = "shuffle_reveal"
+	EventClientSeed     = "client_seed"
+	EventRunItOffer     = "run_it_offer"
+	EventRunItVote      = "run_it_vote"
+	EventRunItAgreed    = "run_it"
+	EventStraddle       = "straddle"
+	EventBombPot        = "bomb_pot"
+	EventBombPotNext    = "bomb_pot_next"
+	EventSitOut         = "sit_out"
+	EventSitIn          = "sit_in"
+	EventMissedBlind    = "missed_blind"
+	EventShow           = "show"
+	EventShowRequest    = "show_request"
+	EventMuck           = "muck"
+	EventState          = "state"
+)
+
+// виды блайндов в BlindPosted
+const (
+	BlindSmall    = "small"
+	BlindBig      = "big"
+	BlindButton   = "button"
+	BlindStraddle = "straddle"
+	BlindBombPot  = "bomb_pot"
+	BlindMissed   = "missed" // пропущенный блайнд вернувшегося игрока, мертвые фишки
+)
+
+// PlayerEntered - игрок вошел в лобби и сел на место seat
+type PlayerEntered struct {
+	PlayerId string `json:"player_id"`
+	Seat     int    `json:"seat"`
+}
+
+// GameStarted - началась раздача. Hand - номер раздачи за столом
+type GameStarted struct {
+	Hand int `json:"hand"`
+}
+
+// Seats - рассадка перед раздачей: игроки по порядку мест и их стеки до анте и блайндов
+type Seats struct {
+	Hand             int           `json:"hand"`
+	GameType         string        `json:"game_type"`
+	BettingStructure string        `json:"betting_structure"`
+	MaxPlayers       int           `json:"max_players"`
+	SmallBlind       int           `json:"small_blind"`
+	BigBlind         int           `json:"big_blind"`
+	Ante             int           `json:"ante"`
+	Players          []PlayerStats `json:"players"`
+}
+
+// ReplayState - то, что кроме рассадки нужно для повтора раздачи. Клиентам не отправляется
+type ReplayState struct {
+	Deck         []Card   `json:"deck"`                  // колода после перемешивания, карты сдаются с начала
+	DealerIndex  int      `json:"dealer_index"`          // место баттона до его передачи в этой раздаче
+	BlindSeats   []int    `json:"blind_seats,omitempty"` // места малого и большого блайнда прошлой раздачи
+	RaiseCap     int      `json:"raise_cap"`
+	ButtonBlind  bool     `json:"button_blind"`
+	RunItMax     int      `json:"run_it_max"`
+	Straddle     bool     `json:"straddle"`
+	BombPot      int      `json:"bomb_pot"` // анте бомб-пота, 0 - обычная раздача
+	RakePercent  float64  `json:"rake_percent"`
+	RakeCap      int      `json:"rake_cap"`
+	NoFlopNoDrop bool     `json:"no_flop_no_drop"`
+	DeadBlinds   []string `json:"dead_blinds,omitempty"` // кто ставит пропущенный блайнд
+}
+
+// CardsShown - игрок открыл карты в конце раздачи
+type CardsShown struct {
+	PlayerId string `json:"player_id"`
+	Cards    []Card `json:"cards"`
+}
+
+// ShowRequested - игрок попросил открыть карты. Нужно только для повтора раздачи, клиентам не отправляется
+type ShowRequested struct {
+	PlayerId string `json:"player_id"`
+}
+
+// PlayerMucked - игрок сбросил проигравшие карты на вскрытии, не показывая их
+type PlayerMucked struct {
+	PlayerId string `json:"player_id"`
+}
+
+// PlayerSatOut - игрок пропускает раздачи со следующей. Reason - request, timeouts или blind
+type PlayerSatOut struct {
+	PlayerId string `json:"player_id"`
+	Reason   string `json:"reason"`
+}
+
+// PlayerSatIn - игрок вернется со следующей раздачи или, если WaitBigBlind, когда до него дойдет большой блайнд
+type PlayerSatIn struct {
+	PlayerId     string `json:"player_id"`
+	WaitBigBlind bool   `json:"wait_big_blind,omitempty"`
+}
+
+// BombPotScheduled - хост попросил сделать раздачу hand бомб-потом
+type BombPotScheduled struct {
+	Hand int `json:"hand"`
+	Ante int `json:"ante"`
+}
+
+// ShuffleCommitted - хеш сида сервера, которым будет перемешана колода раздачи hand
+type ShuffleCommitted struct {
+	Hand       int    `json:"hand"`
+	Commitment string `json:"commitment"`
+}
+
+// PlayerStats - стек игрока. Карты приходят только в конце раздачи
+type PlayerStats struct {
+	Id         string `json:"id"`
+	Seat       int    `json:"seat"`
+	Balance    int    `json:"balance"`
+	SittingOut bool   `json:"sitting_out,omitempty"`
+	Hand       *Hand  `json:"hand,omitempty"`
+}
+
+// PlayersStats - стеки всех игроков стола
+type PlayersStats struct {
+	Players []PlayerStats `json:"players"`
+}
+
+// RoundStarted - началась новая улица (0 - пре-флоп)
+type RoundStarted struct {
+	Round int `json:"round"`
+}
+
+// CardsDealt - игроку розданы карты на руки. Уходит только самому игроку
+type CardsDealt struct {
+	PlayerId string `json:"player_id"`
+	Cards    []Card `json:"cards"`
+}
+
+// CommunityCardsDealt - открыты общие карты. Cards - новые карты, Board - весь борд
+type CommunityCardsDealt struct {
+	Street string `json:"street"`
+	Cards  []Card `json:"cards"`
+	Board  []Card `json:"board"`
+	Run    int    `json:"run,omitempty"` // номер прогона с 1, если борд прогоняется несколько раз
+}
+
+// GameStopped - раздача закончена, выплаты произведены
+type GameStopped struct {
+	Hand int `json:"hand"`
+}
+
+// AllPotsWon - все, кроме одного игрока, сбросили, и он забирает все банки
+type AllPotsWon struct {
+	PlayerId string `json:"player_id"`
+	Amount   int    `json:"amount"` // за вычетом рейка
+	Rake     int    `json:"rake,omitempty"`
+}
+
+// PotAwarded - банк (или его половина в hi-lo) разыгран на вскрытии.
+// Pot - номер банка с единицы, Amount - сколько досталось каждому победителю без учета нечетных фишек,
+// Rake - сколько взято с этой части банка
+type PotAwarded struct {
+	Pot     int      `json:"pot"`
+	Share   string   `json:"share,omitempty"`
+	Run     int      `json:"run,omitempty"` // борд, по которому разыграна часть банка
+	Amount  int      `json:"amount"`
+	Rake    int      `json:"rake,omitempty"`
+	Winners []string `json:"winners"`
+}
+
+// BetReturned - игроку вернулась часть ставки, которую никто не уравнял
+type BetReturned struct {
+	PlayerId string `json:"player_id"`
+	Amount   int    `json:"amount"`
+}
+
+// AnteFailed - игроку не хватает баланса на анте, он выбывает из-за стола
+type AnteFailed struct {
+	PlayerId string `json:"player_id"`
+}
+
+// AnteCollected - собраны анте. Ante - с каждого игрока, Total - всего
+type AnteCollected struct {
+	Ante  int `json:"ante"`
+	Total int `json:"total"`
+}
+
+// BlindPosted - игрок поставил блайнд (small, big, button, straddle или анте бомб-пота)
+type BlindPosted struct {
+	PlayerId string `json:"player_id"`
+	Blind    string `json:"blind"`
+	Amount   int    `json:"amount"`
+}
+
+// PlayerTurn - ход переходит к игроку
+type PlayerTurn struct {
+	PlayerId string `json:"player_id"`
+}
+
+// ButtonMoved - баттон перешел на место seat. PlayerId пустой, если баттон мертвый
+type ButtonMoved struct {
+	PlayerId string `json:"player_id,omitempty"`
+	Seat     int    `json:"seat"`
+}
+
+// BadMove - ход игрока отклонен. Min и Max приходят, если ставка вне допустимого диапазона
+type BadMove struct {
+	PlayerId string `json:"player_id"`
+	Action   string `json:"action"`
+	Amount   int    `json:"amount"`
+	Error    string `json:"error"`
+	Min      int    `json:"min,omitempty"`
+	Max      int    `json:"max,omitempty"`
+}
+
+// AvailableAction - что игрок может сделать, не повышая ставку: call до Amount или check
+type AvailableAction struct {
+	PlayerId string `json:"player_id"`
+	Action   string `json:"action"`
+	Amount   int    `json:"amount"`
+}
+
+// PlayerAction - игрок сделал ход. Amount - его итоговая ставка на улице
+type PlayerAction struct {
+	PlayerId string `json:"player_id"`
+	Action   string `json:"action"`
+	Amount   int    `json:"amount"`
+}
+
+// BlindLevelUp - вырос уровень блайндов. Следующий уровень не приходит на последнем уровне
+type BlindLevelUp struct {
+	Level           int         `json:"level"`
+	SmallBlind      int         `json:"small_blind"`
+	BigBlind        int         `json:"big_blind"`
+	Ante            int         `json:"ante"`
+	NextLevel       *BlindLevel `json:"next_level,omitempty"`
+	TimeToNextLevel float64     `json:"time_to_next_level,omitempty"`
+}
+
+// ActionClock - таймер хода игрока (action_clock и time_bank)
+type ActionClock struct {
+	PlayerId string    `json:"player_id"`
+	Deadline time.Time `json:"deadline"`
+	Seconds  float64   `json:"seconds"`
+	TimeBank float64   `json:"time_bank"`
+}
+
+// PlayerTimeout - время игрока вышло, за него сделан check или fold
+type PlayerTimeout struct {
+	PlayerId string `json:"player_id"`
+	Action   string `json:"action"`
+}
+
+// RunItOffer - торговли больше не будет, игроки могут прогнать оставшийся борд до max_runs раз
+type RunItOffer struct {
+	Players  []string  `json:"players"`
+	MaxRuns  int       `json:"max_runs"`
+	Deadline time.Time `json:"deadline"` // нулевое, если у стола нет move_timeout
+}
+
+// RunItVoted - игрок согласился на runs прогонов. timeout - не успел проголосовать, засчитан один прогон
+type RunItVoted struct {
+	PlayerId string `json:"player_id"`
+	Runs     int    `json:"runs"`
+	Timeout  bool   `json:"timeout,omitempty"`
+}
+
+// RunItAgreed - все проголосовали, борд прогоняется runs раз
+type RunItAgreed struct {
+	Runs int `json:"runs"`
+}
+
+// notify рассылает событие стола с текущей версией схемы
+func (t *PokerTable) notify(recipients []string, eventType string, data any) {
+	t.NotifyObservers(recipients, ObserverMessage{
+		EventType: eventType,
+		EventData: data,
+		LobbyId:   t.Config.TableId.String(),
+		Version:   EventSchemaVersion,
+	})
+}
+
+func newBadMove(playerId, action string, amount int, err error) BadMove {
+	e := BadMove{PlayerId: playerId, Action: action, Amount: amount, Error: err.Error()}
+	var rangeErr *BetRangeError
+	if errors.As(err, &rangeErr) {
+		e.Min, e.Max = rangeErr.Min, rangeErr.Max
+	}
+	return e
+}
@@ -0,0 +1,113 @@
+package holdem
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// eventRecorder запоминает все события стола
+type eventRecorder struct {
+	messages []ObserverMessage
+}
+
+func (r *eventRecorder) Update(recipients []string, data ObserverMessage) {
+	r.messages = append(r.messages, data)
+}
+
+func (r *eventRecorder) byType(eventType string) []any {
+	output := []any{}
+	for _, m := range r.messages {
+		if m.EventType == eventType {
+			output = append(output, m.EventData)
+		}
+	}
+	return output
+}
+
+func TestTypedEvents(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	table.AddObserver(rec)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+
+	require.Equal(t, rec.byType(EventSmallBlind), []any{BlindPosted{PlayerId: p3.GetId(), Blind: BlindSmall, Amount: 50}})
+	require.Equal(t, rec.byType(EventBigBlind), []any{BlindPosted{PlayerId: p1.GetId(), Blind: BlindBig, Amount: 100}})
+	require.Equal(t, rec.byType(EventGetCards)[0], CardsDealt{PlayerId: p1.GetId(), Cards: p1.Hand.Cards})
+
+	err := table.MakeMove(p2.GetId(), "raise", 150)
+	require.ErrorIs(t, err, ErrCantRaise)
+	require.Equal(t, rec.byType(EventBadMove), []any{BadMove{
+		PlayerId: p2.GetId(), Action: "raise", Amount: 150, Error: err.Error(), Min: 200, Max: 1000,
+	}})
+
+	require.NoError(t, table.MakeMove(p2.GetId(), "raise", 300))
+	require.NoError(t, table.MakeMove(p3.GetId(), "fold", 0))
+	require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+	require.Equal(t, rec.byType(EventDo), []any{
+		PlayerAction{PlayerId: p2.GetId(), Action: "raise", Amount: 300},
+		PlayerAction{PlayerId: p3.GetId(), Action: "fold", Amount: 50},
+		PlayerAction{PlayerId: p1.GetId(), Action: "fold", Amount: 100},
+	})
+	require.Equal(t, rec.byType(EventReturnBet), []any{BetReturned{PlayerId: p2.GetId(), Amount: 200}})
+	require.Equal(t, rec.byType(EventWinAll), []any{AllPotsWon{PlayerId: p2.GetId(), Amount: 250}})
+	require.Equal(t, rec.byType(EventStopGame), []any{GameStopped{Hand: 1}})
+
+	stats := rec.byType(EventPlayersStats)
+	last := stats[len(stats)-1].(PlayersStats)
+	require.Len(t, last.Players, 3)
+	for _, p := range last.Players {
+		require.Nil(t, p.Hand) // банк забран без вскрытия, карты никто не открывал
+	}
+
+	for _, m := range rec.messages {
+		require.Equal(t, m.Version, EventSchemaVersion)
+		require.Equal(t, m.LobbyId, table.Config.TableId.String())
+	}
+}
+
+func TestEventJson(t *testing.T) {
+	cases := []struct {
+		TestCaseName string
+		Message      ObserverMessage
+		Expected     string
+	}{
+		{
+			TestCaseName: "Player action",
+			Message:      ObserverMessage{EventDo, PlayerAction{PlayerId: "p1", Action: "call", Amount: 100}, "l1", EventSchemaVersion},
+			Expected:     `{"event_type":"do","event_data":{"player_id":"p1","action":"call","amount":100},"lobby_id":"l1","version":1}`,
+		},
+		{
+			TestCaseName: "Pot half",
+			Message:      ObserverMessage{EventWinPot, PotAwarded{Pot: 1, Share: "low", Amount: 50, Winners: []string{"p1"}}, "l1", EventSchemaVersion},
+			Expected:     `{"event_type":"win_pot","event_data":{"pot":1,"share":"low","amount":50,"winners":["p1"]},"lobby_id":"l1","version":1}`,
+		},
+		{
+			TestCaseName: "Whole pot",
+			Message:      ObserverMessage{EventWinPot, PotAwarded{Pot: 2, Amount: 50, Winners: []string{"p1", "p2"}}, "l1", EventSchemaVersion},
+			Expected:     `{"event_type":"win_pot","event_data":{"pot":2,"amount":50,"winners":["p1","p2"]},"lobby_id":"l1","version":1}`,
+		},
+		{
+			TestCaseName: "Stats without cards",
+			Message:      ObserverMessage{EventPlayersStats, PlayersStats{Players: []PlayerStats{{Id: "p1", Balance: 10}}}, "l1", EventSchemaVersion},
+			Expected:     `{"event_type":"players_stats","event_data":{"players":[{"id":"p1","seat":0,"balance":10}]},"lobby_id":"l1","version":1}`,
+		},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			data, err := json.Marshal(tCase.Message)
+			require.NoError(t, err)
+			require.JSONEq(t, string(data), tCase.Expected)
+		})
+	}
+}
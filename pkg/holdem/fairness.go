@@ -0,0 +1,147 @@
+package holdem
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"regexp"
+)
+
+const (
+	ServerSeedBytes     = 32
+	MaxClientSeedLength = 64
+	ActionClientSeed    = "client_seed" // сообщение игрока с сидом вместо хода
+)
+
+var (
+	ErrBadClientSeed  = fmt.Errorf("client seed must be from 1 to %d latin letters, digits, - or _", MaxClientSeedLength)
+	ErrBadServerSeed  = errors.New("server seed must be 64 hex characters")
+	ErrBadCommitment  = errors.New("server seed does not match the commitment")
+	clientSeedPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// ClientSeed - сид, который игрок добавил в перемешивание колоды
+type ClientSeed struct {
+	PlayerId string `json:"player_id"`
+	Seed     string `json:"seed"`
+}
+
+// ShuffleProof - все, что нужно для проверки перемешивания раздачи. Приходит в событии shuffle_reveal после раздачи
+// @Schema
+type ShuffleProof struct {
+	Hand        int          `json:"hand"`
+	GameType    string       `json:"game_type"`
+	ServerSeed  string       `json:"server_seed"`
+	Commitment  string       `json:"commitment"`
+	ClientSeeds []ClientSeed `json:"client_seeds"`
+}
+
+// NewServerSeed возвращает случайный сид сервера из crypto/rand в hex
+func NewServerSeed() string {
+	b := make([]byte, ServerSeedBytes)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// SeedCommitment - sha256 от hex строки сида сервера, публикуется до раздачи
+func SeedCommitment(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+func ValidateClientSeed(seed string) error {
+	if len(seed) == 0 || len(seed) > MaxClientSeedLength || !clientSeedPattern.MatchString(seed) {
+		return ErrBadClientSeed
+	}
+	return nil
+}
+
+// shuffleKey = sha256(server_seed + ":" + player_id + ":" + seed + ...) по сидам игроков в порядке мест
+func shuffleKey(serverSeed string, clientSeeds []ClientSeed) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(serverSeed))
+	for _, s := range clientSeeds {
+		h.Write([]byte(":" + s.PlayerId + ":" + s.Seed))
+	}
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// fairShuffle перемешивает колоду Фишером-Йетсом на потоке ChaCha8 (C2SP chacha8rand) с ключом shuffleKey.
+// Для i от len-1 до 1 берется j = x mod (i+1), где x - первое 64-битное число потока не меньше 2^64 mod (i+1)
+func fairShuffle(deck []Card, key [32]byte) {
+	src := mathrand.NewChaCha8(key)
+	for i := len(deck) - 1; i > 0; i-- {
+		bound := uint64(i + 1)
+		threshold := -bound % bound
+		x := src.Uint64()
+		for x < threshold {
+			x = src.Uint64()
+		}
+		j := x % bound
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+}
+
+// VerifyShuffle проверяет, что сид сервера совпадает с опубликованным хешем, и возвращает колоду раздачи
+// в порядке сдачи (карты берутся с начала)
+func VerifyShuffle(p ShuffleProof) ([]Card, error) {
+	if b, err := hex.DecodeString(p.ServerSeed); err != nil || len(b) != ServerSeedBytes {
+		return nil, ErrBadServerSeed
+	}
+	if SeedCommitment(p.ServerSeed) != p.Commitment {
+		return nil, ErrBadCommitment
+	}
+	for _, s := range p.ClientSeeds {
+		if err := ValidateClientSeed(s.Seed); err != nil {
+			return nil, err
+		}
+	}
+	rules, ok := gameRules[p.GameType]
+	if !ok {
+		return nil, ErrUnknownGameType
+	}
+	deck := rules.Deck()
+	fairShuffle(deck, shuffleKey(p.ServerSeed, p.ClientSeeds))
+	return deck, nil
+}
+
+// SetClientSeed задает сид игрока, он используется со следующей раздачи и до замены
+func (t *PokerTable) SetClientSeed(playerId, seed string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.checkClientSeed(playerId, seed); err != nil {
+		t.notify([]string{playerId}, EventBadMove, newBadMove(playerId, ActionClientSeed, 0, err))
+		return err
+	}
+	t.Meta.ClientSeeds[playerId] = seed
+	t.notify([]string{playerId}, EventClientSeed, ClientSeed{PlayerId: playerId, Seed: seed})
+	return nil
+}
+
+func (t *PokerTable) checkClientSeed(playerId, seed string) error {
+	if err := ValidateClientSeed(seed); err != nil {
+		return err
+	}
+	_, inGame := t.Meta.Players[playerId]
+	_, inQuery := t.Meta.Query[playerId]
+	if !inGame && !inQuery {
+		return ErrPlayerNotFound
+	}
+	return nil
+}
+
+// revealShuffle после раздачи раскрывает сид сервера тем, кому раздали карты, и публикует хеш сида следующей раздачи.
+// Зрители сид не получают
+func (t *PokerTable) revealShuffle() {
+	if t.Meta.Shuffle != nil {
+		t.notify(t.Meta.Dealt, EventShuffleReveal, *t.Meta.Shuffle)
+	}
+	if commitment := t.Config.Shuffler.Commit(); commitment != "" {
+		t.notify(t.public(), EventShuffleCommit, ShuffleCommitted{Hand: t.Meta.HandCount + 1, Commitment: commitment})
+	}
+}
@@ -0,0 +1,109 @@
+package holdem
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvablyFairShuffle(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 0)
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	table.AddObserver(rec)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+	require.NoError(t, table.AddPlayer(p1))
+	require.NoError(t, table.AddPlayer(p2))
+	require.ErrorIs(t, table.SetClientSeed(p1.GetId(), "bad seed"), ErrBadClientSeed)
+	require.Equal(t, rec.byType(EventBadMove), []any{BadMove{PlayerId: p1.GetId(), Action: ActionClientSeed, Error: ErrBadClientSeed.Error()}})
+	require.ErrorIs(t, table.SetClientSeed(uuid.NewString(), "abc"), ErrPlayerNotFound)
+	require.NoError(t, table.SetClientSeed(p1.GetId(), "lucky-7"))
+
+	// хеш сида публикуется каждому вошедшему до начала раздачи
+	commits := rec.byType(EventShuffleCommit)
+	require.Len(t, commits, 2)
+	commit := commits[0].(ShuffleCommitted)
+	require.Equal(t, commit, ShuffleCommitted{Hand: 1, Commitment: commit.Commitment})
+	require.Equal(t, commits[1], commit)
+
+	table.StartGame()
+	require.NoError(t, table.MakeMove(table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], "fold", 0))
+	reveals := rec.byType(EventShuffleReveal)
+	require.Len(t, reveals, 1)
+	proof := reveals[0].(ShuffleProof)
+	require.Equal(t, proof.Commitment, commit.Commitment)
+	require.Equal(t, proof.ClientSeeds, []ClientSeed{{PlayerId: p1.GetId(), Seed: "lucky-7"}})
+	next := rec.byType(EventShuffleCommit)[2].(ShuffleCommitted)
+	require.Equal(t, next.Hand, 2)
+	require.NotEqual(t, next.Commitment, commit.Commitment)
+
+	// по раскрытому сиду колода восстанавливается: карты раздаются с начала по порядку мест
+	deck, err := VerifyShuffle(proof)
+	require.NoError(t, err)
+	require.Len(t, deck, 52)
+	require.Equal(t, rec.byType(EventGetCards), []any{
+		CardsDealt{PlayerId: p1.GetId(), Cards: deck[0:2]},
+		CardsDealt{PlayerId: p2.GetId(), Cards: deck[2:4]},
+	})
+
+	withoutClientSeed := proof
+	withoutClientSeed.ClientSeeds = nil
+	other, err := VerifyShuffle(withoutClientSeed)
+	require.NoError(t, err)
+	require.NotEqual(t, other, deck)
+
+	testCases := []struct {
+		name  string
+		proof func(p ShuffleProof) ShuffleProof
+		err   error
+	}{
+		{"other seed", func(p ShuffleProof) ShuffleProof { p.ServerSeed = NewServerSeed(); return p }, ErrBadCommitment},
+		{"short seed", func(p ShuffleProof) ShuffleProof { p.ServerSeed = p.ServerSeed[:10]; return p }, ErrBadServerSeed},
+		{"not hex", func(p ShuffleProof) ShuffleProof { p.ServerSeed = strings.Repeat("z", 64); return p }, ErrBadServerSeed},
+		{"bad client seed", func(p ShuffleProof) ShuffleProof {
+			p.ClientSeeds = []ClientSeed{{PlayerId: p1.GetId(), Seed: ""}}
+			return p
+		}, ErrBadClientSeed},
+		{"unknown game", func(p ShuffleProof) ShuffleProof { p.GameType = "stud"; return p }, ErrUnknownGameType},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := VerifyShuffle(tc.proof(proof))
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestShuffleRevealAfterFold(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	config.Shuffler = NewCryptoShuffler()
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	hands := &[]HandHistory{}
+	table.AddObserver(rec)
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.StartGame()
+
+	// карты никто не открыл, но раздачу все равно можно проверить
+	require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+	require.Empty(t, rec.byType(EventShow))
+	reveals := rec.byType(EventShuffleReveal)
+	require.Len(t, reveals, 1)
+	require.Equal(t, reveals[0].(ShuffleProof).Hand, 1)
+	commits := rec.byType(EventShuffleCommit)
+	require.Equal(t, commits[len(commits)-1].(ShuffleCommitted).Hand, 2)
+	require.NotNil(t, (*hands)[0].Shuffle)
+	require.Equal(t, (*hands)[0].ForPlayer(p1.GetId()).Shuffle, (*hands)[0].Shuffle)
+
+	deck, err := VerifyShuffle(*(*hands)[0].Shuffle)
+	require.NoError(t, err)
+	require.Equal(t, deck, (*hands)[0].Replay.Deck)
+}
@@ -0,0 +1,324 @@
+package holdem
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const StreetPreflop = "preflop"
+
+// HandHistory - запись одной раздачи: рассадка, стеки, блайнды, карты, ходы по улицам, борд и выплаты
+// @Schema
+type HandHistory struct {
+	Id               int64           `json:"id"`
+	TableId          string          `json:"table_id"`
+	Hand             int             `json:"hand"` // номер раздачи за столом
+	GameType         string          `json:"game_type"`
+	BettingStructure string          `json:"betting_structure"`
+	MaxPlayers       int             `json:"max_players"`
+	SmallBlind       int             `json:"small_blind"`
+	BigBlind         int             `json:"big_blind"`
+	Ante             int             `json:"ante"`
+	Dealer           string          `json:"dealer"` // пустой, если баттон мертвый
+	Button           int             `json:"button"` // место баттона, с единицы
+	Seats            []HistorySeat   `json:"seats"`
+	Blinds           []BlindPosted   `json:"blinds"`
+	Actions          []HistoryAction `json:"actions"`
+	Board            []Card          `json:"board"`
+	Boards           [][]Card        `json:"boards,omitempty"` // если борд прогоняли несколько раз, Board - первый из них
+	Pots             []PotAwarded    `json:"pots"`
+	Pot              int             `json:"pot"` // все фишки, внесенные в банк
+	Rake             int             `json:"rake"`
+	Showdown         bool            `json:"showdown"`
+	ShowOrder        []string        `json:"show_order,omitempty"` // кто открыл или сбросил карты, в порядке вскрытия
+	StartedAt        time.Time       `json:"started_at"`
+	FinishedAt       time.Time       `json:"finished_at"`
+	Shuffle          *ShuffleProof   `json:"shuffle,omitempty"` // раскрытый сид, по нему можно проверить колоду
+	Replay           *ReplayLog      `json:"replay,omitempty"`  // только на сервере, игрокам не отдается
+}
+
+// ReplayLog - сид, позиция баттона и все попытки ходов. Вместе с рассадкой этого хватает, чтобы повторить раздачу
+type ReplayLog struct {
+	ReplayState
+	Moves []LoggedMove `json:"moves"`
+}
+
+// LoggedMove - попытка хода, в том числе отклоненная. Offset - секунды от начала раздачи
+type LoggedMove struct {
+	PlayerId string  `json:"player_id"`
+	Action   string  `json:"action"`
+	Amount   int     `json:"amount"`
+	Timeout  bool    `json:"timeout,omitempty"` // ход сделан по таймеру
+	Offset   float64 `json:"offset"`
+}
+
+// HistorySeat - игрок раздачи. Stack - до анте и блайндов, Won - сколько он забрал из банка
+type HistorySeat struct {
+	Seat     int    `json:"seat"` // с единицы
+	PlayerId string `json:"player_id"`
+	Stack    int    `json:"stack"`
+	Cards    []Card `json:"cards,omitempty"`
+	Folded   string `json:"folded,omitempty"` // улица, на которой игрок сбросил карты
+	Shown    bool   `json:"shown"`            // игрок открыл карты
+	Mucked   bool   `json:"mucked,omitempty"` // сбросил карты на вскрытии, не показывая
+	Won      int    `json:"won"`
+	// игрок сидел в стороне и не участвовал в раздаче
+	SittingOut bool `json:"sitting_out,omitempty"`
+}
+
+// HistoryAction - ход игрока. Amount - итоговая ставка игрока на улице, для uncalled - сколько вернулось
+type HistoryAction struct {
+	Street   string `json:"street"`
+	PlayerId string `json:"player_id"`
+	Action   string `json:"action"` // check, fold, call, bet, raise, allin, uncalled
+	Amount   int    `json:"amount"`
+}
+
+// Seat возвращает место игрока
+func (h *HandHistory) Seat(playerId string) (HistorySeat, bool) {
+	ind := slices.IndexFunc(h.Seats, func(s HistorySeat) bool { return s.PlayerId == playerId })
+	if ind == -1 {
+		return HistorySeat{}, false
+	}
+	return h.Seats[ind], true
+}
+
+// ForPlayer скрывает чужие карты, которые не были открыты на вскрытии, и данные для повтора.
+// Сид раздачи видят только те, кому раздали карты
+func (h HandHistory) ForPlayer(playerId string) HandHistory {
+	h.Replay = nil
+	if s, ok := h.Seat(playerId); !ok || len(s.Cards) == 0 {
+		h.Shuffle = nil
+	}
+	h.Seats = slices.Clone(h.Seats)
+	for i, s := range h.Seats {
+		if s.PlayerId != playerId && !s.Shown {
+			h.Seats[i].Cards = nil
+		}
+	}
+	return h
+}
+
+// HandRecorder собирает историю раздач из событий стола и передает готовые раздачи в save.
+// Если после раздачи и до начала следующей кто то открыл карты, save вызывается еще раз с той же раздачей
+// (тот же TableId и Hand). Один рекордер может слушать несколько столов
+type HandRecorder struct {
+	save  func(h HandHistory)
+	mu    sync.Mutex
+	hands map[string]*handRecord
+}
+
+type handRecord struct {
+	history  HandHistory
+	street   string
+	bets     map[string]int // ставки на текущей улице
+	invested map[string]int // вклад в банк за раздачу
+	timeout  bool           // следующий do - ход по таймеру, он уже записан
+	stopped  bool
+	saved    bool
+}
+
+func NewHandRecorder(save func(h HandHistory)) *HandRecorder {
+	return &HandRecorder{save: save, hands: make(map[string]*handRecord)}
+}
+
+func (r *HandRecorder) Update(recipients []string, data ObserverMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if seats, ok := data.EventData.(Seats); ok {
+		r.hands[data.LobbyId] = newHandRecord(data.LobbyId, seats)
+		return
+	}
+	rec, ok := r.hands[data.LobbyId]
+	if !ok {
+		return
+	}
+	if rec.saved {
+		// открытые после раздачи карты и раскрытый после этого сид дописываются в сохраненную раздачу
+		switch data.EventData.(type) {
+		case ShowRequested:
+			rec.apply(data)
+		case CardsShown, ShuffleProof:
+			rec.apply(data)
+			r.save(rec.snapshot())
+		}
+		return
+	}
+	if stats, ok := data.EventData.(PlayersStats); ok && rec.stopped {
+		rec.history = rec.finish(stats)
+		rec.saved = true
+		r.save(rec.snapshot())
+		return
+	}
+	rec.apply(data)
+}
+
+func newHandRecord(tableId string, seats Seats) *handRecord {
+	rec := &handRecord{
+		history: HandHistory{
+			TableId:          tableId,
+			Hand:             seats.Hand,
+			GameType:         seats.GameType,
+			BettingStructure: seats.BettingStructure,
+			MaxPlayers:       seats.MaxPlayers,
+			SmallBlind:       seats.SmallBlind,
+			BigBlind:         seats.BigBlind,
+			Ante:             seats.Ante,
+			Seats:            make([]HistorySeat, 0, len(seats.Players)),
+			Blinds:           []BlindPosted{},
+			Actions:          []HistoryAction{},
+			Board:            []Card{},
+			Pots:             []PotAwarded{},
+			StartedAt:        time.Now(),
+		},
+		street:   StreetPreflop,
+		bets:     make(map[string]int),
+		invested: make(map[string]int),
+	}
+	for _, p := range seats.Players {
+		rec.history.Seats = append(rec.history.Seats, HistorySeat{Seat: p.Seat + 1, PlayerId: p.Id, Stack: p.Balance, SittingOut: p.SittingOut})
+	}
+	return rec
+}
+
+func (rec *handRecord) seat(playerId string) *HistorySeat {
+	ind := slices.IndexFunc(rec.history.Seats, func(s HistorySeat) bool { return s.PlayerId == playerId })
+	if ind == -1 {
+		return nil
+	}
+	return &rec.history.Seats[ind]
+}
+
+func (rec *handRecord) apply(data ObserverMessage) {
+	h := &rec.history
+	switch e := data.EventData.(type) {
+	case AnteFailed:
+		h.Seats = slices.DeleteFunc(h.Seats, func(s HistorySeat) bool { return s.PlayerId == e.PlayerId })
+	case AnteCollected:
+		for _, s := range h.Seats {
+			if !s.SittingOut {
+				rec.invested[s.PlayerId] += e.Ante
+			}
+		}
+	case CardsDealt:
+		if s := rec.seat(e.PlayerId); s != nil {
+			s.Cards = e.Cards
+		}
+	case ReplayState:
+		h.Replay = &ReplayLog{ReplayState: e, Moves: []LoggedMove{}}
+	case ShuffleProof:
+		h.Shuffle = &e
+	case BadMove:
+		if replayable(e.Action) {
+			rec.logMove(LoggedMove{PlayerId: e.PlayerId, Action: e.Action, Amount: e.Amount})
+		}
+	case ShowRequested:
+		rec.logMove(LoggedMove{PlayerId: e.PlayerId, Action: ActionShow})
+	case PlayerTimeout:
+		rec.logMove(LoggedMove{PlayerId: e.PlayerId, Action: e.Action, Timeout: true})
+		rec.timeout = true
+	case CardsShown:
+		if s := rec.seat(e.PlayerId); s != nil {
+			s.Shown = true
+		}
+		h.ShowOrder = append(h.ShowOrder, e.PlayerId)
+	case PlayerMucked:
+		if s := rec.seat(e.PlayerId); s != nil {
+			s.Mucked = true
+		}
+		h.ShowOrder = append(h.ShowOrder, e.PlayerId)
+	case ButtonMoved:
+		h.Dealer = e.PlayerId
+		h.Button = e.Seat + 1
+	case BlindPosted:
+		h.Blinds = append(h.Blinds, e)
+		if !deadBlind(e.Blind) {
+			rec.bets[e.PlayerId] += e.Amount
+		}
+		rec.invested[e.PlayerId] += e.Amount
+	case PlayerAction:
+		if !rec.timeout {
+			rec.logMove(LoggedMove{PlayerId: e.PlayerId, Action: e.Action, Amount: e.Amount})
+		}
+		rec.timeout = false
+		h.Actions = append(h.Actions, HistoryAction{Street: rec.street, PlayerId: e.PlayerId, Action: e.Action, Amount: e.Amount})
+		switch e.Action {
+		case "fold":
+			if s := rec.seat(e.PlayerId); s != nil {
+				s.Folded = rec.street
+			}
+		case "check":
+		default:
+			rec.invested[e.PlayerId] += e.Amount - rec.bets[e.PlayerId]
+			rec.bets[e.PlayerId] = e.Amount
+		}
+	case BetReturned:
+		h.Actions = append(h.Actions, HistoryAction{Street: rec.street, PlayerId: e.PlayerId, Action: "uncalled", Amount: e.Amount})
+		rec.bets[e.PlayerId] -= e.Amount
+		rec.invested[e.PlayerId] -= e.Amount
+	case CommunityCardsDealt:
+		rec.street = e.Street
+		clear(rec.bets)
+		if e.Run > 1 {
+			h.Boards[e.Run-1] = e.Board
+			return
+		}
+		h.Board = e.Board
+		if e.Run == 1 {
+			h.Boards[0] = e.Board
+		}
+	case RunItVoted:
+		rec.logMove(LoggedMove{PlayerId: e.PlayerId, Action: ActionRunIt, Amount: e.Runs, Timeout: e.Timeout})
+	case RunItAgreed:
+		if e.Runs > 1 {
+			h.Boards = make([][]Card, e.Runs)
+		}
+	case AllPotsWon:
+		h.Pots = append(h.Pots, PotAwarded{Pot: 1, Amount: e.Amount, Rake: e.Rake, Winners: []string{e.PlayerId}})
+		h.Rake += e.Rake
+	case PotAwarded:
+		h.Showdown = true
+		h.Pots = append(h.Pots, e)
+		h.Rake += e.Rake
+	case GameStopped:
+		rec.stopped = true
+	}
+}
+
+// deadBlind показывает, что фишки блайнда идут в банк, но не в ставку улицы
+func deadBlind(blind string) bool {
+	return blind == BlindMissed || blind == BlindBombPot
+}
+
+func (rec *handRecord) logMove(move LoggedMove) {
+	if rec.history.Replay == nil {
+		return
+	}
+	move.Offset = time.Since(rec.history.StartedAt).Seconds()
+	rec.history.Replay.Moves = append(rec.history.Replay.Moves, move)
+}
+
+// snapshot - копия истории, которую не изменят карты, открытые после сохранения
+func (rec *handRecord) snapshot() HandHistory {
+	h := rec.history
+	h.Seats = slices.Clone(h.Seats)
+	h.ShowOrder = slices.Clone(h.ShowOrder)
+	return h
+}
+
+// finish подводит итог раздачи по стекам игроков после выплат
+func (rec *handRecord) finish(stats PlayersStats) HandHistory {
+	h := rec.history
+	h.FinishedAt = time.Now()
+	for _, v := range rec.invested {
+		h.Pot += v
+	}
+	for i, s := range h.Seats {
+		ind := slices.IndexFunc(stats.Players, func(p PlayerStats) bool { return p.Id == s.PlayerId })
+		if ind != -1 {
+			h.Seats[i].Won = stats.Players[ind].Balance - s.Stack + rec.invested[s.PlayerId]
+		}
+	}
+	return h
+}
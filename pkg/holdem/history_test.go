@@ -0,0 +1,124 @@
+package holdem
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandRecorder(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 10, 0, false, 1488)
+	table := NewPokerTable(config)
+	hands := &[]HandHistory{}
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 500}  //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+	require.NoError(t, table.MakeMove(p2.GetId(), "raise", 300))
+	require.NoError(t, table.MakeMove(p3.GetId(), "allin", 0))
+	require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+	require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+	require.Equal(t, table.Meta.GameStarted, false)
+
+	require.Len(t, *hands, 1)
+	h := (*hands)[0]
+	require.Equal(t, h.TableId, table.Config.TableId.String())
+	require.Equal(t, h.Dealer, p2.GetId())
+	require.Equal(t, h.Blinds, []BlindPosted{
+		{PlayerId: p3.GetId(), Blind: BlindSmall, Amount: 50},
+		{PlayerId: p1.GetId(), Blind: BlindBig, Amount: 100},
+	})
+	require.Equal(t, h.Actions, []HistoryAction{
+		{Street: StreetPreflop, PlayerId: p2.GetId(), Action: "raise", Amount: 300},
+		{Street: StreetPreflop, PlayerId: p3.GetId(), Action: "allin", Amount: 490},
+		{Street: StreetPreflop, PlayerId: p1.GetId(), Action: "fold", Amount: 100},
+		{Street: StreetPreflop, PlayerId: p2.GetId(), Action: "call", Amount: 490},
+	})
+	require.Len(t, h.Board, 5)
+	require.True(t, h.Showdown)
+	require.Equal(t, h.Pot, 30+490*2+100)
+
+	// выигрыши раздают весь банк, фишки не появляются и не пропадают
+	won, delta := 0, 0
+	for _, s := range h.Seats {
+		won += s.Won
+		delta += table.Meta.Players[s.PlayerId].GetBalance() - s.Stack
+	}
+	require.Equal(t, won, h.Pot)
+	require.Equal(t, delta, 0)
+
+	p1Seat, _ := h.Seat(p1.GetId())
+	require.Equal(t, p1Seat.Folded, StreetPreflop)
+	require.False(t, p1Seat.Shown)
+	require.Len(t, p1Seat.Cards, 2)
+	p2Seat, _ := h.Seat(p2.GetId())
+	require.True(t, p2Seat.Shown)
+
+	// чужие не открытые карты скрыты, свои и открытые видны
+	require.Len(t, mustSeat(t, h.ForPlayer(p2.GetId()), p1.GetId()).Cards, 0)
+	require.Len(t, mustSeat(t, h.ForPlayer(p2.GetId()), p3.GetId()).Cards, 2)
+	require.Len(t, mustSeat(t, h.ForPlayer(p1.GetId()), p1.GetId()).Cards, 2)
+	require.Len(t, mustSeat(t, h, p1.GetId()).Cards, 2)
+
+	export := ExportPokerStars(h.ForPlayer(p1.GetId()), p1.GetId(), map[string]string{p1.GetId(): "alice", p2.GetId(): "bob"})
+	for _, line := range []string{
+		"PokerStars Hand #0: Hold'em No Limit (50/100) - " + h.StartedAt.UTC().Format("2006/01/02 15:04:05") + " UTC",
+		"Table '" + h.TableId + "' 10-max Seat #2 is the button",
+		"Seat 1: alice (1000 in chips)",
+		"bob: posts the ante 10",
+		"alice: posts big blind 100",
+		"Dealt to alice " + pokerStarsCards(p1.Hand.Cards),
+		"bob: raises 200 to 300",
+		"00000000-0000-0000-0000-000000000003: raises 190 to 490 and is all-in",
+		"alice: folds",
+		"bob: calls 190",
+		"*** TURN *** " + pokerStarsCards(h.Board[:3]) + " " + pokerStarsCards(h.Board[3:4]),
+		"*** SHOW DOWN ***",
+		"bob: shows " + pokerStarsCards(p2.Hand.Cards),
+		"Total pot 1110 | Rake 0",
+		"Seat 1: alice (big blind) folded before Flop",
+	} {
+		require.Contains(t, export, line+"\n")
+	}
+	require.NotContains(t, export, "Dealt to bob")
+}
+
+func TestExportPokerStarsUncalledBet(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	hands := &[]HandHistory{}
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 500}  //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+	require.NoError(t, table.MakeMove(p2.GetId(), "raise", 300))
+	require.NoError(t, table.MakeMove(p3.GetId(), "fold", 0))
+	require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+
+	require.Len(t, *hands, 1)
+	h := (*hands)[0]
+	require.False(t, h.Showdown)
+	require.Equal(t, mustSeat(t, h, p2.GetId()).Won, 250)
+	export := ExportPokerStars(h, p2.GetId(), nil)
+	require.True(t, strings.Contains(export, "Uncalled bet (200) returned to "+p2.GetId()+"\n"))
+	require.True(t, strings.Contains(export, "Seat 2: "+p2.GetId()+" (button) collected (250)\n"))
+	require.False(t, strings.Contains(export, "posts the ante"))
+	require.False(t, strings.Contains(export, "*** SHOW DOWN ***"))
+}
+
+func mustSeat(t *testing.T, h HandHistory, playerId string) HistorySeat {
+	s, ok := h.Seat(playerId)
+	require.True(t, ok)
+	return s
+}
@@ -0,0 +1,104 @@
+package holdem
+
+import (
+	"errors"
+)
+
+const ActionBombPot = "bomb_pot" // просьба хоста сделать следующую раздачу бомб-потом
+
+var (
+	ErrStraddleFixedLimit  = errors.New("straddle is not allowed in fixed limit")
+	ErrStraddleButtonBlind = errors.New("straddle is not allowed with button blind")
+	ErrBadBombPot          = errors.New("bomb pot ante and frequency cant be negative, frequency needs ante")
+	ErrBombPotDisabled     = errors.New("bomb pots are disabled at this table")
+	ErrNotHost             = errors.New("only the host can do this")
+)
+
+// SetStraddle включает страддл: игрок после большого блайнда вслепую ставит два больших блайнда
+func (cfg *TableConfig) SetStraddle(straddle bool) error {
+	if straddle && cfg.BettingStructure == BettingFixedLimit {
+		return ErrStraddleFixedLimit
+	}
+	if straddle && cfg.ButtonBlind {
+		return ErrStraddleButtonBlind
+	}
+	cfg.Straddle = straddle
+	return nil
+}
+
+// SetBombPot задает анте бомб-пота и то, как часто он играется. every = 0 - только по просьбе хоста,
+// ante = 0 - бомб-потов нет
+func (cfg *TableConfig) SetBombPot(ante, every int) error {
+	if ante < 0 || every < 0 || (every > 0 && ante == 0) {
+		return ErrBadBombPot
+	}
+	cfg.BombPotAnte = ante
+	cfg.BombPotEvery = every
+	return nil
+}
+
+// straddled показывает, ставится ли страддл в этой раздаче. В хендз апе страддла нет
+func (t *PokerTable) straddled() bool {
+	return t.Config.Straddle && !t.Config.ButtonBlind && t.activePlayers() > 2
+}
+
+// betStraddle - игрок после большого блайнда ставит страддл и торгуется на префлопе последним
+func (t *PokerTable) betStraddle() {
+	straddler := t.Meta.PlayersOrder[t.afterSeat(t.Meta.BigBlindSeat, 1)]
+	bet := min(t.Config.SmallBlind*4, t.Meta.Players[straddler].GetBalance())
+	t.putChips(t.Meta.Players[straddler], bet)
+	t.notify(t.public(), EventStraddle, BlindPosted{PlayerId: straddler, Blind: BlindStraddle, Amount: bet})
+	t.Meta.CurrentBet = max(t.Meta.CurrentBet, bet)
+	t.Meta.LastRaise = t.betSize() * 2 // страддл - вслепую сделанный рейз, следующий рейз минимум до двух страддлов
+	t.Meta.RaisesCount = 2
+}
+
+// scheduleBombPot решает, будет ли начинающаяся раздача бомб-потом: каждая BombPotEvery-я или по просьбе хоста
+func (t *PokerTable) scheduleBombPot() {
+	every := t.Config.BombPotEvery
+	t.Meta.BombPot = t.Config.BombPotAnte > 0 && (t.Meta.BombPotNext || (every > 0 && t.Meta.HandCount%every == 0))
+	t.Meta.BombPotNext = false
+}
+
+// RequestBombPot - хост просит сделать следующую раздачу бомб-потом
+func (t *PokerTable) RequestBombPot(playerId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.checkBombPot(playerId); err != nil {
+		t.notify([]string{playerId}, EventBadMove, newBadMove(playerId, ActionBombPot, 0, err))
+		return err
+	}
+	t.Meta.BombPotNext = true
+	t.notify(t.public(), EventBombPotNext, BombPotScheduled{Hand: t.Meta.HandCount + 1, Ante: t.Config.BombPotAnte})
+	return nil
+}
+
+func (t *PokerTable) checkBombPot(playerId string) error {
+	if t.Config.BombPotAnte == 0 {
+		return ErrBombPotDisabled
+	}
+	if t.Config.HostId.String() != playerId {
+		return ErrNotHost
+	}
+	return nil
+}
+
+// betBombPot - вместо блайндов каждый ставит анте бомб-пота. Торговли на префлопе нет, раздача начинается с флопа
+func (t *PokerTable) betBombPot() {
+	for _, k := range t.Meta.PlayersOrder {
+		if t.sittingOut(k) {
+			continue
+		}
+		p := t.Meta.Players[k]
+		bet := min(t.Config.BombPotAnte, p.GetBalance())
+		p.ChangeBalance(-bet)
+		p.SetTotalBet(p.GetTotalBet() + bet)
+		p.SetStatus(true)
+		t.notify(t.public(), EventBombPot, BlindPosted{PlayerId: k, Blind: BlindBombPot, Amount: bet})
+	}
+}
+
+// bombPotPreflop показывает, что идет префлоп бомб-пота, на котором никто не ходит
+func (t *PokerTable) bombPotPreflop() bool {
+	return t.Meta.BombPot && t.Meta.CurrentRound == 0
+}
@@ -0,0 +1,173 @@
+package holdem
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStraddle(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	require.NoError(t, config.SetStraddle(true))
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	hands := &[]HandHistory{}
+	table.AddObserver(rec)
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //straddle
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	p4 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000004"), Balance: 1000} //bb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.AddPlayer(p4)
+	table.StartGame()
+
+	require.Equal(t, rec.byType(EventStraddle), []any{BlindPosted{PlayerId: p1.GetId(), Blind: BlindStraddle, Amount: 200}})
+	require.Equal(t, table.Meta.CurrentBet, 200)
+	// первым ходит следующий за страддлом, рейз минимум до двух страддлов
+	require.Equal(t, table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], p2.GetId())
+	var rangeErr *BetRangeError
+	require.ErrorAs(t, table.MakeMove(p2.GetId(), "raise", 300), &rangeErr)
+	require.Equal(t, rangeErr.Min, 400)
+	require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+	require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+	require.NoError(t, table.MakeMove(p4.GetId(), "call", 0))
+
+	// страддл ходит последним и может поднять ставку
+	require.Equal(t, table.Meta.CurrentRound, 0)
+	require.Equal(t, table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], p1.GetId())
+	require.NoError(t, table.MakeMove(p1.GetId(), "check", 0))
+	require.Equal(t, table.Meta.CurrentRound, 1)
+	for table.Meta.GameStarted {
+		require.NoError(t, table.MakeMove(table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], "check", 0))
+	}
+
+	require.Len(t, *hands, 1)
+	h := (*hands)[0]
+	require.Equal(t, h.Pot, 800)
+	export := ExportPokerStars(h, p1.GetId(), nil)
+	require.Contains(t, export, "00000000-0000-0000-0000-000000000001: posts straddle 200\n")
+	res, err := ReplayHand(h)
+	require.NoError(t, err)
+	require.True(t, res.Consistent)
+}
+
+func TestStraddleHeadsUp(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	require.NoError(t, config.SetStraddle(true))
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	table.AddObserver(rec)
+	table.AddPlayer(&Player{Id: uuid.New(), Balance: 1000})
+	table.AddPlayer(&Player{Id: uuid.New(), Balance: 1000})
+	table.StartGame()
+
+	require.Empty(t, rec.byType(EventStraddle))
+	require.Equal(t, table.Meta.CurrentBet, 100)
+}
+
+func TestBombPot(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	require.NoError(t, config.SetBombPot(100, 2))
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	hands := &[]HandHistory{}
+	table.AddObserver(rec)
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000}
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.Config.HostId = p1.Id
+	playOut := func() {
+		for table.Meta.GameStarted {
+			require.NoError(t, table.MakeMove(table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], "check", 0))
+		}
+	}
+
+	// первая раздача обычная
+	table.StartGame()
+	require.Empty(t, rec.byType(EventBombPot))
+	require.NoError(t, table.MakeMove(p2.GetId(), "fold", 0))
+	require.NoError(t, table.MakeMove(p3.GetId(), "fold", 0))
+
+	// вторая - бомб-пот: блайндов нет, раздача начинается с флопа
+	blinds := len(rec.byType(EventBigBlind))
+	table.StartGame()
+	require.Equal(t, rec.byType(EventBombPot), []any{
+		BlindPosted{PlayerId: p1.GetId(), Blind: BlindBombPot, Amount: 100},
+		BlindPosted{PlayerId: p2.GetId(), Blind: BlindBombPot, Amount: 100},
+		BlindPosted{PlayerId: p3.GetId(), Blind: BlindBombPot, Amount: 100},
+	})
+	require.Len(t, rec.byType(EventBigBlind), blinds)
+	require.Equal(t, table.Meta.CurrentRound, 1)
+	require.Len(t, table.Meta.CommunityCards, 3)
+	require.Equal(t, table.Meta.PlayerTurnInd, table.afterSeat(table.Meta.ButtonSeat, 1))
+	// на префлопе бомб-пота ход никому не передается
+	posted := slices.IndexFunc(rec.messages, func(m ObserverMessage) bool { return m.EventType == EventBombPot })
+	canDo := slices.IndexFunc(rec.messages[posted:], func(m ObserverMessage) bool { return m.EventType == EventCanDo })
+	flop := slices.IndexFunc(rec.messages[posted:], func(m ObserverMessage) bool { return m.EventType == EventCommunityCards })
+	require.Less(t, flop, canDo)
+
+	require.ErrorIs(t, table.RequestBombPot(p2.GetId()), ErrNotHost)
+	require.Equal(t, rec.byType(EventBadMove), []any{BadMove{PlayerId: p2.GetId(), Action: ActionBombPot, Error: ErrNotHost.Error()}})
+	require.NoError(t, table.RequestBombPot(p1.GetId()))
+	require.Equal(t, rec.byType(EventBombPotNext), []any{BombPotScheduled{Hand: 3, Ante: 100}})
+	playOut()
+
+	// третья - по просьбе хоста
+	table.StartGame()
+	require.Len(t, rec.byType(EventBombPot), 6)
+	playOut()
+
+	require.Len(t, *hands, 3)
+	h := (*hands)[2]
+	require.Equal(t, h.Pot, 300)
+	require.Equal(t, h.Replay.BombPot, 100)
+	for _, a := range h.Actions {
+		require.NotEqual(t, a.Street, StreetPreflop)
+	}
+	require.True(t, strings.Contains(ExportPokerStars(h, p1.GetId(), nil), "posts bomb pot 100\n"))
+	res, err := ReplayHand(h)
+	require.NoError(t, err)
+	require.True(t, res.Consistent)
+	require.Equal(t, res.Hand.Blinds, h.Blinds)
+}
+
+func TestHomeGameConfig(t *testing.T) {
+	testCases := []struct {
+		name  string
+		apply func(cfg *TableConfig) error
+		err   error
+	}{
+		{"straddle", func(cfg *TableConfig) error { return cfg.SetStraddle(true) }, nil},
+		{"straddle fixed limit", func(cfg *TableConfig) error {
+			cfg.BettingStructure = BettingFixedLimit
+			return cfg.SetStraddle(true)
+		}, ErrStraddleFixedLimit},
+		{"straddle button blind", func(cfg *TableConfig) error {
+			cfg.ButtonBlind = true
+			return cfg.SetStraddle(true)
+		}, ErrStraddleButtonBlind},
+		{"bomb pot on request", func(cfg *TableConfig) error { return cfg.SetBombPot(100, 0) }, nil},
+		{"negative ante", func(cfg *TableConfig) error { return cfg.SetBombPot(-1, 0) }, ErrBadBombPot},
+		{"frequency without ante", func(cfg *TableConfig) error { return cfg.SetBombPot(0, 5) }, ErrBadBombPot},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1)
+			require.ErrorIs(t, tc.apply(cfg), tc.err)
+		})
+	}
+
+	table := NewPokerTable(NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1))
+	require.ErrorIs(t, table.RequestBombPot(uuid.Nil.String()), ErrBombPotDisabled)
+}
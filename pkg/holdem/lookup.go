@@ -0,0 +1,275 @@
+package holdem
+
+import (
+	"slices"
+)
+
+// HandRank - сила пятикарточной руки одним числом. Чем больше, тем сильнее рука.
+// Старшие биты - сила комбинации с учетом правил игры, затем вид комбинации и номер руки внутри вида
+type HandRank uint32
+
+// Category возвращает вид комбинации (HighCard ... RoyalFlush)
+func (r HandRank) Category() int {
+	return int(r>>12) & 0xF
+}
+
+// простые числа для достоинств 2..A. Произведение однозначно задает набор достоинств руки
+var rankPrimes = [13]uint32{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+// cardCode - карта, закодированная для поиска по таблицам:
+// биты 16-28 - достоинство одним битом, 12-15 - масть одним битом, 8-11 - достоинство, 0-7 - простое число
+type cardCode uint32
+
+func encodeCard(c Card) cardCode {
+	rank := uint32(c.Value - 2)
+	var suit uint32
+	if len(c.Suit) != 0 {
+		switch c.Suit[0] {
+		case 'S':
+			suit = 1
+		case 'H':
+			suit = 2
+		case 'D':
+			suit = 4
+		case 'C':
+			suit = 8
+		}
+	}
+	return cardCode(1<<(16+rank) | suit<<12 | rank<<8 | rankPrimes[rank])
+}
+
+// LookupEvaluator оценивает руки из 5-7 карт по заранее построенным таблицам (больше карт тоже можно, но медленнее)
+type LookupEvaluator struct {
+	flush     [1 << 13]HandRank   // маска пяти разных достоинств одной масти -> флеш или стрит-флеш
+	unique    [1 << 13]HandRank   // маска пяти разных достоинств -> старшая карта или стрит
+	paired    map[uint32]HandRank // произведение простых чисел -> руки с повторяющимися достоинствами
+	wheelMask uint32              // маска стрита, в котором туз играет младшей картой
+}
+
+var (
+	standardLookup  = NewLookupEvaluator(false)
+	shortDeckLookup = NewLookupEvaluator(true)
+)
+
+// NewLookupEvaluator строит таблицы для обычной колоды или для шорт дека
+// (колода от шестерки, флеш старше фулл-хауса, стрит A-6-7-8-9)
+func NewLookupEvaluator(shortDeck bool) *LookupEvaluator {
+	lowest, wheel := 0, []int{3, 2, 1, 0, 12} // 5-4-3-2-A
+	if shortDeck {
+		lowest, wheel = 4, []int{7, 6, 5, 4, 12} // 9-8-7-6-A
+	}
+	e := &LookupEvaluator{paired: make(map[uint32]HandRank)}
+	for _, r := range wheel {
+		e.wheelMask |= 1 << r
+	}
+
+	type pattern struct {
+		cards    [5]int // достоинства пяти карт
+		ranks    []int  // различные достоинства по убыванию значимости
+		flush    bool
+		category int
+		strength int
+	}
+	patterns := make([]pattern, 0, 7462)
+	var ranks [5]int
+	var build func(pos, maxRank int)
+	build = func(pos, maxRank int) {
+		if pos == 5 {
+			for _, flush := range []bool{false, true} {
+				category, ordered, ok := classifyRanks(ranks, flush, e.wheelMask)
+				if !ok {
+					continue
+				}
+				patterns = append(patterns, pattern{
+					cards:    ranks,
+					ranks:    ordered,
+					flush:    flush,
+					category: category,
+					strength: rankStrength(Combination{Rank: category, ShortDeck: shortDeck}),
+				})
+			}
+			return
+		}
+		for r := maxRank; r >= lowest; r-- {
+			// одного достоинства не больше четырех карт
+			if pos >= 4 && ranks[pos-4] == r {
+				continue
+			}
+			ranks[pos] = r
+			build(pos+1, r)
+		}
+	}
+	build(0, 12)
+
+	slices.SortFunc(patterns, func(a, b pattern) int {
+		if a.strength != b.strength {
+			return a.strength - b.strength
+		}
+		return slices.Compare(a.ranks, b.ranks)
+	})
+	index := 0
+	for i, p := range patterns {
+		if i == 0 || p.category != patterns[i-1].category {
+			index = 0
+		}
+		index++
+		rank := HandRank(p.strength<<16 | p.category<<12 | index)
+		var mask, product uint32 = 0, 1
+		for _, r := range p.cards {
+			mask |= 1 << r
+			product *= rankPrimes[r]
+		}
+		switch {
+		case p.flush:
+			e.flush[mask] = rank
+		case p.category == HighCard || p.category == Straight:
+			e.unique[mask] = rank
+		default:
+			e.paired[product] = rank
+		}
+	}
+	return e
+}
+
+// classifyRanks определяет вид комбинации по пяти достоинствам (по невозрастанию) и упорядочивает их
+// по значимости для сравнения. Для стритов значима только старшая карта
+func classifyRanks(ranks [5]int, flush bool, wheelMask uint32) (int, []int, bool) {
+	counts := make(map[int]int, 5)
+	var mask uint32
+	for _, r := range ranks {
+		counts[r]++
+		mask |= 1 << r
+	}
+	if flush && len(counts) != 5 {
+		return 0, nil, false
+	}
+	ordered := slices.Clone(ranks[:])
+	slices.SortStableFunc(ordered, func(a, b int) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return b - a
+	})
+	ordered = slices.Compact(ordered)
+
+	if len(counts) == 5 {
+		top := -1
+		if ranks[0]-ranks[4] == 4 {
+			top = ranks[0]
+		} else if mask == wheelMask {
+			top = ranks[1]
+		}
+		switch {
+		case top >= 0 && flush && top == 12:
+			return RoyalFlush, []int{top}, true
+		case top >= 0 && flush:
+			return StraightFlush, []int{top}, true
+		case top >= 0:
+			return Straight, []int{top}, true
+		case flush:
+			return Flush, ordered, true
+		}
+		return HighCard, ordered, true
+	}
+	switch counts[ordered[0]] {
+	case 4:
+		return FourOfAKind, ordered, true
+	case 3:
+		if len(counts) == 2 {
+			return FullHouse, ordered, true
+		}
+		return ThreeOfAKind, ordered, true
+	}
+	if len(counts) == 3 {
+		return TwoPairs, ordered, true
+	}
+	return OnePair, ordered, true
+}
+
+func (e *LookupEvaluator) rank5(c0, c1, c2, c3, c4 cardCode) HandRank {
+	mask := uint32(c0|c1|c2|c3|c4) >> 16
+	if c0&c1&c2&c3&c4&0xF000 != 0 {
+		if r := e.flush[mask]; r != 0 {
+			return r
+		}
+	}
+	if r := e.unique[mask]; r != 0 {
+		return r
+	}
+	return e.paired[uint32(c0&0xFF)*uint32(c1&0xFF)*uint32(c2&0xFF)*uint32(c3&0xFF)*uint32(c4&0xFF)]
+}
+
+// best находит лучшие пять карт из 5-7 закодированных карт и возвращает их индексы
+func (e *LookupEvaluator) best(codes []cardCode) (HandRank, [5]int) {
+	var best HandRank
+	var bestInd [5]int
+	n := len(codes)
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			for c := b + 1; c < n; c++ {
+				for d := c + 1; d < n; d++ {
+					for f := d + 1; f < n; f++ {
+						if r := e.rank5(codes[a], codes[b], codes[c], codes[d], codes[f]); r > best {
+							best = r
+							bestInd = [5]int{a, b, c, d, f}
+						}
+					}
+				}
+			}
+		}
+	}
+	return best, bestInd
+}
+
+// Rank возвращает силу лучшей руки из 5-7 карт, не собирая сами карты. Для массовых расчетов
+func (e *LookupEvaluator) Rank(cards []Card) HandRank {
+	var buf [7]cardCode
+	codes := buf[:0]
+	for _, c := range cards {
+		codes = append(codes, encodeCard(c))
+	}
+	r, _ := e.best(codes)
+	return r
+}
+
+// Evaluate возвращает силу лучшей руки из 5-7 карт и сами пять карт в порядке значимости
+// (сначала старшие группы, в колесе туз последний)
+func (e *LookupEvaluator) Evaluate(cards []Card) (HandRank, [5]Card) {
+	var five [5]Card
+	if len(cards) < 5 {
+		return 0, five
+	}
+	var buf [7]cardCode
+	codes := buf[:0]
+	for _, c := range cards {
+		codes = append(codes, encodeCard(c))
+	}
+	r, ind := e.best(codes)
+	var mask uint32
+	counts := make(map[int]int, 5)
+	for i, j := range ind {
+		five[i] = cards[j]
+		counts[cards[j].Value]++
+		mask |= 1 << (cards[j].Value - 2)
+	}
+	category := r.Category()
+	wheel := (category == Straight || category == StraightFlush) && mask == e.wheelMask
+	slices.SortStableFunc(five[:], func(a, b Card) int {
+		if wheel && (a.Value == 14 || b.Value == 14) {
+			return cmpAce(a, b)
+		}
+		if counts[a.Value] != counts[b.Value] {
+			return counts[b.Value] - counts[a.Value]
+		}
+		return b.Value - a.Value
+	})
+	return r, five
+}
+
+// в колесе туз идет последним
+func cmpAce(a, b Card) int {
+	if a.Value == 14 {
+		return 1
+	}
+	return -1
+}
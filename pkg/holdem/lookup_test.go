@@ -0,0 +1,155 @@
+package holdem
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// forEachFive перебирает все пятикарточные руки колоды
+func forEachFive(deck []Card, f func(five []Card)) {
+	five := make([]Card, 5)
+	n := len(deck)
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			for c := b + 1; c < n; c++ {
+				for d := c + 1; d < n; d++ {
+					for e := d + 1; e < n; e++ {
+						five[0], five[1], five[2], five[3], five[4] = deck[a], deck[b], deck[c], deck[d], deck[e]
+						f(five)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLookupEvaluatorClasses(t *testing.T) {
+	expected := map[int]int{
+		HighCard:      1277,
+		OnePair:       2860,
+		TwoPairs:      858,
+		ThreeOfAKind:  858,
+		Straight:      10,
+		Flush:         1277,
+		FullHouse:     156,
+		FourOfAKind:   156,
+		StraightFlush: 9,
+		RoyalFlush:    1,
+	}
+	classes := make(map[HandRank]bool)
+	forEachFive(GetStandardDeck(), func(five []Card) {
+		classes[standardLookup.Rank(five)] = true
+	})
+	require.Len(t, classes, 7462)
+	counts := make(map[int]int)
+	for r := range classes {
+		counts[r.Category()]++
+	}
+	require.Equal(t, counts, expected)
+}
+
+// TestLookupCrossCheck сверяет таблицы с прежним оценщиком на всех пятикарточных руках
+func TestLookupCrossCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("exhaustive check")
+	}
+	cases := []struct {
+		TestCaseName string
+		Deck         []Card
+		Evaluator    *LookupEvaluator
+		ShortDeck    bool
+	}{
+		{TestCaseName: "Standard deck", Deck: GetStandardDeck(), Evaluator: standardLookup},
+		{TestCaseName: "Short deck", Deck: GetShortDeck(), Evaluator: shortDeckLookup, ShortDeck: true},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			naive := make(map[HandRank]Combination)
+			forEachFive(tCase.Deck, func(five []Card) {
+				rank, best := tCase.Evaluator.Evaluate(five)
+				old := naiveEvaluateHand(five[:2], five[2:], tCase.ShortDeck)
+				if rank.Category() != old.Rank {
+					t.Fatalf("%v: category %d, expected %d", five, rank.Category(), old.Rank)
+				}
+				// прежний оценщик для стритов и флешей хранит только старшую карту
+				if compareCards(best[:len(old.CompareCards)], old.CompareCards) != 0 {
+					t.Fatalf("%v: best five %v, expected %v", five, best, old.CompareCards)
+				}
+				naive[rank] = old
+			})
+
+			// чем больше ранг, тем сильнее рука и по прежним правилам сравнения
+			ranks := make([]HandRank, 0, len(naive))
+			for r := range naive {
+				ranks = append(ranks, r)
+			}
+			slices.Sort(ranks)
+			for i := 1; i < len(ranks); i++ {
+				require.GreaterOrEqual(t, CompareCombinations(naive[ranks[i]], naive[ranks[i-1]]), 0)
+			}
+		})
+	}
+}
+
+func TestLookupSevenCards(t *testing.T) {
+	r := rand.New(rand.NewSource(1488))
+	deck := GetStandardDeck()
+	for i := 0; i < 100000; i++ {
+		r.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		seven := deck[:7]
+		rank, best := standardLookup.Evaluate(seven)
+		require.Equal(t, rank.Category(), naiveEvaluateHand(seven[:2], seven[2:], false).Rank)
+		require.Equal(t, standardLookup.Rank(best[:]), rank)
+		for _, c := range best {
+			require.Contains(t, seven, c)
+		}
+	}
+}
+
+func randomHands(n, size int) [][]Card {
+	r := rand.New(rand.NewSource(1488))
+	deck := GetStandardDeck()
+	hands := make([][]Card, n)
+	for i := range hands {
+		r.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+		hands[i] = slices.Clone(deck[:size])
+	}
+	return hands
+}
+
+func BenchmarkLookupRank5(b *testing.B) {
+	hands := randomHands(1024, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		standardLookup.Rank(hands[i%len(hands)])
+	}
+}
+
+func BenchmarkLookupRank7(b *testing.B) {
+	hands := randomHands(1024, 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		standardLookup.Rank(hands[i%len(hands)])
+	}
+}
+
+func BenchmarkEvaluateHand7(b *testing.B) {
+	hands := randomHands(1024, 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hand := hands[i%len(hands)]
+		EvaluateHand(hand[:2], hand[2:])
+	}
+}
+
+func BenchmarkNaiveEvaluateHand7(b *testing.B) {
+	hands := randomHands(1024, 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hand := hands[i%len(hands)]
+		naiveEvaluateHand(hand[:2], hand[2:], false)
+	}
+}
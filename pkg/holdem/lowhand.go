@@ -0,0 +1,93 @@
+package holdem
+
+import "slices"
+
+// LowQualifier - старшая карта младшей руки не может быть выше восьмерки (8-or-better)
+const LowQualifier = 8
+
+// LowHand - младшая рука омахи хай-лоу. Values - достоинства пяти разных карт по убыванию, туз считается единицей.
+// Лучшая младшая рука - колесо A-2-3-4-5
+type LowHand struct {
+	Values [5]int
+}
+
+func lowValue(c Card) int {
+	if c.Value == 14 {
+		return 1
+	}
+	return c.Value
+}
+
+// CompareLow сравнивает две младшие руки
+// return 1 if a лучше b (ниже)
+// return -1 if a хуже b
+// return 0 if a == b
+func CompareLow(a, b LowHand) int {
+	for i := range a.Values {
+		if a.Values[i] < b.Values[i] {
+			return 1
+		} else if a.Values[i] > b.Values[i] {
+			return -1
+		}
+	}
+	return 0
+}
+
+// EvaluateOmahaLow находит лучшую младшую руку из ровно двух карт игрока и ровно трех со стола.
+// Второе значение false, если младшей руки не собрать
+func EvaluateOmahaLow(playerHand []Card, communityCards []Card) (LowHand, bool) {
+	var best LowHand
+	found := false
+	for i := 0; i < len(playerHand); i++ {
+		for j := i + 1; j < len(playerHand); j++ {
+			for a := 0; a < len(communityCards); a++ {
+				for b := a + 1; b < len(communityCards); b++ {
+					for c := b + 1; c < len(communityCards); c++ {
+						low, ok := makeLow(playerHand[i], playerHand[j], communityCards[a], communityCards[b], communityCards[c])
+						if ok && (!found || CompareLow(low, best) > 0) {
+							best = low
+							found = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return best, found
+}
+
+func makeLow(cards ...Card) (LowHand, bool) {
+	var low LowHand
+	for i, c := range cards {
+		v := lowValue(c)
+		if v > LowQualifier || slices.Contains(low.Values[:i], v) {
+			return LowHand{}, false
+		}
+		low.Values[i] = v
+	}
+	slices.SortFunc(low.Values[:], func(a, b int) int { return b - a })
+	return low, true
+}
+
+// DeterminateLowWinners определяет игроков с лучшей младшей рукой.
+// Второе значение false, если ни у кого нет младшей руки и банк целиком уходит старшей
+func DeterminateLowWinners(communityCards []Card, players map[string]IPlayer) ([]string, bool) {
+	var bestPlayers []string
+	var bestLow LowHand
+	for id, player := range players {
+		if player.GetFold() {
+			continue
+		}
+		low, ok := EvaluateOmahaLow(player.GetHand().Cards, communityCards)
+		if !ok {
+			continue
+		}
+		if len(bestPlayers) == 0 || CompareLow(low, bestLow) > 0 {
+			bestPlayers = append(bestPlayers[:0], id)
+			bestLow = low
+		} else if CompareLow(low, bestLow) == 0 {
+			bestPlayers = append(bestPlayers, id)
+		}
+	}
+	return bestPlayers, len(bestPlayers) != 0
+}
@@ -0,0 +1,148 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateOmahaLow(t *testing.T) {
+	cases := []struct {
+		TestCaseName   string
+		PlayerHand     []Card
+		CommunityCards []Card
+		ExpectedOk     bool
+		ExpectedLow    LowHand
+	}{
+		{
+			TestCaseName: "Wheel is the best low",
+			PlayerHand: []Card{
+				{Suit: "Hearts", Value: 14}, {Suit: "Spades", Value: 2},
+				{Suit: "Diamonds", Value: 13}, {Suit: "Clubs", Value: 13},
+			},
+			CommunityCards: []Card{
+				{Suit: "Hearts", Value: 3}, {Suit: "Hearts", Value: 4},
+				{Suit: "Clubs", Value: 5}, {Suit: "Hearts", Value: 8},
+				{Suit: "Clubs", Value: 12},
+			},
+			ExpectedOk:  true,
+			ExpectedLow: LowHand{Values: [5]int{5, 4, 3, 2, 1}},
+		},
+		{
+			TestCaseName: "Nine does not qualify",
+			PlayerHand: []Card{
+				{Suit: "Hearts", Value: 14}, {Suit: "Spades", Value: 2},
+				{Suit: "Diamonds", Value: 13}, {Suit: "Clubs", Value: 13},
+			},
+			CommunityCards: []Card{
+				{Suit: "Hearts", Value: 3}, {Suit: "Hearts", Value: 9},
+				{Suit: "Clubs", Value: 10}, {Suit: "Hearts", Value: 11},
+				{Suit: "Clubs", Value: 4},
+			},
+			ExpectedOk: false,
+		},
+		{
+			TestCaseName: "Exactly two cards from hand",
+			PlayerHand: []Card{
+				{Suit: "Hearts", Value: 14}, {Suit: "Spades", Value: 2},
+				{Suit: "Diamonds", Value: 3}, {Suit: "Clubs", Value: 4},
+			},
+			CommunityCards: []Card{
+				{Suit: "Hearts", Value: 5}, {Suit: "Hearts", Value: 13},
+				{Suit: "Clubs", Value: 13}, {Suit: "Hearts", Value: 12},
+				{Suit: "Clubs", Value: 8},
+			},
+			ExpectedOk: false,
+		},
+		{
+			TestCaseName: "Paired card is skipped",
+			PlayerHand: []Card{
+				{Suit: "Hearts", Value: 2}, {Suit: "Spades", Value: 2},
+				{Suit: "Diamonds", Value: 7}, {Suit: "Clubs", Value: 13},
+			},
+			CommunityCards: []Card{
+				{Suit: "Hearts", Value: 3}, {Suit: "Diamonds", Value: 2},
+				{Suit: "Clubs", Value: 6}, {Suit: "Hearts", Value: 4},
+				{Suit: "Clubs", Value: 12},
+			},
+			ExpectedOk:  true,
+			ExpectedLow: LowHand{Values: [5]int{7, 6, 4, 3, 2}},
+		},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			low, ok := EvaluateOmahaLow(tCase.PlayerHand, tCase.CommunityCards)
+			require.Equal(t, ok, tCase.ExpectedOk)
+			require.Equal(t, low, tCase.ExpectedLow)
+		})
+	}
+}
+
+func TestHiLoPayMoney(t *testing.T) {
+	board := []Card{
+		{Suit: "Hearts", Value: 3}, {Suit: "Hearts", Value: 4},
+		{Suit: "Clubs", Value: 6}, {Suit: "Hearts", Value: 13},
+		{Suit: "Spades", Value: 13},
+	}
+	highHand := []Card{
+		{Suit: "Diamonds", Value: 13}, {Suit: "Clubs", Value: 12},
+		{Suit: "Diamonds", Value: 10}, {Suit: "Clubs", Value: 9},
+	}
+	lowHand := []Card{
+		{Suit: "Diamonds", Value: 14}, {Suit: "Clubs", Value: 2},
+		{Suit: "Diamonds", Value: 11}, {Suit: "Clubs", Value: 10},
+	}
+	noLowHand := []Card{
+		{Suit: "Diamonds", Value: 12}, {Suit: "Spades", Value: 12},
+		{Suit: "Diamonds", Value: 9}, {Suit: "Spades", Value: 9},
+	}
+	cases := []struct {
+		TestCaseName     string
+		Hands            [][]Card
+		PotAmount        int
+		ExpectedBalances []int
+	}{
+		{
+			TestCaseName:     "High and low split, odd chip to high",
+			Hands:            [][]Card{highHand, lowHand},
+			PotAmount:        1001,
+			ExpectedBalances: []int{501, 500},
+		},
+		{
+			TestCaseName:     "Quartered low",
+			Hands:            [][]Card{highHand, lowHand, lowHand},
+			PotAmount:        1200,
+			ExpectedBalances: []int{600, 300, 300},
+		},
+		{
+			TestCaseName:     "No qualifying low, high scoops",
+			Hands:            [][]Card{highHand, noLowHand},
+			PotAmount:        1000,
+			ExpectedBalances: []int{1000, 0},
+		},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+			require.NoError(t, config.SetGameType(GameOmahaHiLo))
+			table := NewPokerTable(config)
+			players := make([]*Player, 0, len(tCase.Hands))
+			applicants := make([]string, 0, len(tCase.Hands))
+			for _, hand := range tCase.Hands {
+				p := &Player{Id: uuid.New(), Hand: Hand{Cards: hand}}
+				players = append(players, p)
+				applicants = append(applicants, p.GetId())
+				table.Meta.Players[p.GetId()] = p
+				table.Meta.PlayersOrder = append(table.Meta.PlayersOrder, p.GetId())
+			}
+			table.Meta.CommunityCards = board
+			table.Meta.Pots = []Pot{{Amount: tCase.PotAmount, Applicants: applicants}}
+			table.PayMoney()
+			for i, p := range players {
+				require.Equal(t, p.Balance, tCase.ExpectedBalances[i])
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ type ObserverMessage struct {
 	EventType string      `json:"event_type"`
 	EventData interface{} `json:"event_data"`
 	LobbyId   string      `json:"lobby_id"`
+	Version   int         `json:"version"` // EventSchemaVersion
 }
 
 type Logger struct{}
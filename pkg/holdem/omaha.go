@@ -0,0 +1,66 @@
+package holdem
+
+const (
+	GameOmaha     = "omaha"
+	GameOmahaHiLo = "omaha_hilo"
+)
+
+type omahaRules struct{}
+
+func (omahaRules) Name() string                    { return GameOmaha }
+func (omahaRules) Deck() []Card                    { return GetStandardDeck() }
+func (omahaRules) HoleCards() int                  { return 4 }
+func (omahaRules) Streets() []Street               { return holdemStreets }
+func (omahaRules) DefaultBettingStructure() string { return BettingPotLimit }
+
+func (omahaRules) Evaluate(playerHand []Card, communityCards []Card) Combination {
+	return EvaluateOmahaHand(playerHand, communityCards)
+}
+
+func (r omahaRules) Showdown(communityCards []Card, applicants map[string]IPlayer) []PotShare {
+	return highShowdown(r.Evaluate, communityCards, applicants)
+}
+
+// omahaHiLoRules - омаха хай-лоу 8-or-better. Раздача и старшая рука как в омахе
+type omahaHiLoRules struct {
+	omahaRules
+}
+
+func (omahaHiLoRules) Name() string { return GameOmahaHiLo }
+
+// Showdown делит банк пополам между лучшей старшей и лучшей младшей рукой. Если младшей руки ни у кого нет,
+// банк целиком уходит старшей
+func (r omahaHiLoRules) Showdown(communityCards []Card, applicants map[string]IPlayer) []PotShare {
+	winners, _ := DeterminateWinnerBy(r.Evaluate, communityCards, applicants)
+	lowWinners, ok := DeterminateLowWinners(communityCards, applicants)
+	if !ok {
+		return []PotShare{{Winners: winners}}
+	}
+	return []PotShare{{Name: "high", Winners: winners}, {Name: "low", Winners: lowWinners}}
+}
+
+// EvaluateOmahaHand.
+// Лучшая комбинация в омахе: ровно две карты из руки и ровно три со стола
+func EvaluateOmahaHand(playerHand []Card, communityCards []Card) Combination {
+	var best HandRank
+	var bestFive []Card
+	for i := 0; i < len(playerHand); i++ {
+		for j := i + 1; j < len(playerHand); j++ {
+			for a := 0; a < len(communityCards); a++ {
+				for b := a + 1; b < len(communityCards); b++ {
+					for c := b + 1; c < len(communityCards); c++ {
+						five := []Card{playerHand[i], playerHand[j], communityCards[a], communityCards[b], communityCards[c]}
+						if r := standardLookup.Rank(five); r > best {
+							best = r
+							bestFive = five
+						}
+					}
+				}
+			}
+		}
+	}
+	if bestFive == nil {
+		return Combination{}
+	}
+	return lookupCombination(standardLookup, bestFive, nil)
+}
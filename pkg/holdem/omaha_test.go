@@ -0,0 +1,88 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateOmahaHand(t *testing.T) {
+	cases := []struct {
+		TestCaseName   string
+		PlayerHand     []Card
+		CommunityCards []Card
+		ExpectedRank   int
+	}{
+		{
+			TestCaseName: "One heart in hand is not a flush",
+			PlayerHand: []Card{
+				{Suit: "Hearts", Value: 10}, {Suit: "Spades", Value: 9},
+				{Suit: "Diamonds", Value: 8}, {Suit: "Clubs", Value: 2},
+			},
+			CommunityCards: []Card{
+				{Suit: "Hearts", Value: 14}, {Suit: "Hearts", Value: 13},
+				{Suit: "Hearts", Value: 12}, {Suit: "Hearts", Value: 11},
+				{Suit: "Clubs", Value: 3},
+			},
+			ExpectedRank: Straight,
+		},
+		{
+			TestCaseName: "Board trips with one pair card in hand is not a full house",
+			PlayerHand: []Card{
+				{Suit: "Hearts", Value: 13}, {Suit: "Spades", Value: 9},
+				{Suit: "Diamonds", Value: 8}, {Suit: "Clubs", Value: 2},
+			},
+			CommunityCards: []Card{
+				{Suit: "Hearts", Value: 7}, {Suit: "Spades", Value: 7},
+				{Suit: "Diamonds", Value: 7}, {Suit: "Clubs", Value: 13},
+				{Suit: "Clubs", Value: 4},
+			},
+			ExpectedRank: ThreeOfAKind,
+		},
+		{
+			TestCaseName: "Two suited cards in hand make a flush",
+			PlayerHand: []Card{
+				{Suit: "Hearts", Value: 5}, {Suit: "Hearts", Value: 9},
+				{Suit: "Diamonds", Value: 8}, {Suit: "Clubs", Value: 2},
+			},
+			CommunityCards: []Card{
+				{Suit: "Hearts", Value: 14}, {Suit: "Hearts", Value: 13},
+				{Suit: "Hearts", Value: 3}, {Suit: "Spades", Value: 11},
+				{Suit: "Clubs", Value: 3},
+			},
+			ExpectedRank: Flush,
+		},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			combination := EvaluateOmahaHand(tCase.PlayerHand, tCase.CommunityCards)
+			require.Equal(t, combination.Rank, tCase.ExpectedRank)
+		})
+	}
+}
+
+func TestOmahaTable(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	require.NoError(t, config.SetGameType(GameOmaha))
+	require.NoError(t, config.SetBettingStructure("", 0))
+	require.Equal(t, config.BettingStructure, BettingPotLimit)
+	table := NewPokerTable(config)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.StartGame()
+
+	require.Len(t, p1.Hand.Cards, 4)
+	require.Len(t, p2.Hand.Cards, 4)
+	require.NoError(t, table.MakeMove(p1.GetId(), "call", 0))
+	require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, table.MakeMove(p1.GetId(), "check", 0))
+		require.NoError(t, table.MakeMove(p2.GetId(), "check", 0))
+	}
+	require.Equal(t, table.Meta.GameStarted, false)
+	require.Equal(t, p1.Balance+p2.Balance, 2000)
+}
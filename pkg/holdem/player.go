@@ -33,33 +33,23 @@ type IPlayer interface {
 	SetHand(h Hand)
 	GetLastBet() int
 	SetLastBet(bet int)
-	ToJson(withCards bool) map[string]any
+	GetTotalBet() int // сколько игрок вложил в банк за всю раздачу
+	SetTotalBet(bet int)
 	fmt.Stringer
 }
 
 type Hand struct {
-	Cards [2]Card `json:"cards,omitempty"`
+	Cards []Card `json:"cards,omitempty"` // 2 карты в холдеме, 4 в омахе
 }
 
 type Player struct {
-	Id      uuid.UUID `json:"id"`
-	Balance int       `json:"balance"`
-	Status  bool      `json:"-"`
-	LastBet int       `json:"-"`
-	Hand    Hand      `json:"-"`
-	IsFold  bool      `json:"-"`
-}
-
-func (p *Player) ToJson(withCards bool) map[string]any {
-	item := map[string]any{
-		"id":      p.Id,
-		"balance": p.Balance,
-	}
-	if withCards {
-		item["hand"] = p.Hand
-	}
-	fmt.Println(item)
-	return item
+	Id       uuid.UUID `json:"id"`
+	Balance  int       `json:"balance"`
+	Status   bool      `json:"-"`
+	LastBet  int       `json:"-"`
+	TotalBet int       `json:"-"`
+	Hand     Hand      `json:"-"`
+	IsFold   bool      `json:"-"`
 }
 
 func (p *Player) String() string {
@@ -106,6 +96,14 @@ func (p *Player) SetLastBet(bet int) { // только положительны
 	p.LastBet = bet
 }
 
+func (p *Player) GetTotalBet() int {
+	return p.TotalBet
+}
+
+func (p *Player) SetTotalBet(bet int) {
+	p.TotalBet = bet
+}
+
 func (p *Player) SetHand(h Hand) {
 	p.Hand = h
 }
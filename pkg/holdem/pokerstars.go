@@ -0,0 +1,281 @@
+package holdem
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	pokerStarsGames = map[string]string{
+		GameHoldem:    "Hold'em",
+		GameOmaha:     "Omaha",
+		GameOmahaHiLo: "Omaha Hi/Lo",
+		GameShortDeck: "6+ Hold'em",
+	}
+	pokerStarsLimits = map[string]string{
+		BettingNoLimit:    "No Limit",
+		BettingPotLimit:   "Pot Limit",
+		BettingFixedLimit: "Limit",
+	}
+	pokerStarsBlinds = map[string]string{
+		BlindSmall:    "small blind",
+		BlindBig:      "big blind",
+		BlindButton:   "button blind",
+		BlindStraddle: "straddle",
+		BlindBombPot:  "bomb pot",
+		BlindMissed:   "missed blind",
+	}
+	pokerStarsRanks = "23456789TJQKA"
+	pokerStarsRuns  = []string{"FIRST", "SECOND", "THIRD"}
+	pokerStarsTimes = []string{"", "once", "twice", "three times"}
+)
+
+// pokerStarsCard записывает карту как в PokerStars: Ah, Td
+func pokerStarsCard(c Card) string {
+	if c.Value < 2 || c.Value > 14 || c.Suit == "" {
+		return "??"
+	}
+	return string(pokerStarsRanks[c.Value-2]) + strings.ToLower(c.Suit[:1])
+}
+
+func pokerStarsCards(cards []Card) string {
+	output := make([]string, 0, len(cards))
+	for _, c := range cards {
+		output = append(output, pokerStarsCard(c))
+	}
+	return "[" + strings.Join(output, " ") + "]"
+}
+
+func pokerStarsStreet(street string) string {
+	return strings.ToUpper(street[:1]) + street[1:]
+}
+
+// ExportPokerStars записывает раздачу в текстовом формате PokerStars, который понимают трекеры.
+// hero - игрок, от лица которого записана раздача (его карты приходят в Dealt to), names - имена игроков по id
+func ExportPokerStars(h HandHistory, hero string, names map[string]string) string {
+	name := func(id string) string {
+		if n, ok := names[id]; ok && n != "" {
+			return n
+		}
+		return id
+	}
+	game, ok := pokerStarsGames[h.GameType]
+	if !ok {
+		game = h.GameType
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "PokerStars Hand #%d: %s %s (%d/%d) - %s UTC\n",
+		h.Id, game, pokerStarsLimits[h.BettingStructure], h.SmallBlind, h.BigBlind,
+		h.StartedAt.UTC().Format("2006/01/02 15:04:05"),
+	)
+	button := h.Button
+	if button == 0 { // раздачи, записанные до появления номеров мест
+		dealer, _ := h.Seat(h.Dealer)
+		button = dealer.Seat
+	}
+	fmt.Fprintf(&b, "Table '%s' %d-max Seat #%d is the button\n", h.TableId, h.MaxPlayers, button)
+	for _, s := range h.Seats {
+		sittingOut := ""
+		if s.SittingOut {
+			sittingOut = " is sitting out"
+		}
+		fmt.Fprintf(&b, "Seat %d: %s (%d in chips)%s\n", s.Seat, name(s.PlayerId), s.Stack, sittingOut)
+	}
+	if h.Ante != 0 {
+		for _, s := range h.Seats {
+			if !s.SittingOut {
+				fmt.Fprintf(&b, "%s: posts the ante %d\n", name(s.PlayerId), h.Ante)
+			}
+		}
+	}
+	for _, blind := range h.Blinds {
+		fmt.Fprintf(&b, "%s: posts %s %d\n", name(blind.PlayerId), pokerStarsBlinds[blind.Blind], blind.Amount)
+	}
+
+	b.WriteString("*** HOLE CARDS ***\n")
+	if s, ok := h.Seat(hero); ok && len(s.Cards) != 0 {
+		fmt.Fprintf(&b, "Dealt to %s %s\n", name(hero), pokerStarsCards(s.Cards))
+	}
+
+	bets := make(map[string]int)
+	dead := make(map[string]int)
+	currentBet := 0
+	for _, blind := range h.Blinds {
+		if deadBlind(blind.Blind) {
+			dead[blind.PlayerId] += blind.Amount
+			continue
+		}
+		bets[blind.PlayerId] += blind.Amount
+		currentBet = max(currentBet, bets[blind.PlayerId])
+	}
+	stacks := make(map[string]int)
+	for _, s := range h.Seats {
+		stacks[s.PlayerId] = s.Stack - h.Ante - bets[s.PlayerId] - dead[s.PlayerId]
+	}
+	opened := 0
+	street := StreetPreflop
+	for _, a := range h.Actions {
+		if a.Street != street {
+			street = a.Street
+			opened = openPokerStarsStreets(&b, h.GameType, "", h.Board, opened, streetBoardSize(h.GameType, street))
+			clear(bets)
+			currentBet = 0
+		}
+		p := name(a.PlayerId)
+		delta := a.Amount - bets[a.PlayerId]
+		switch a.Action {
+		case "check":
+			fmt.Fprintf(&b, "%s: checks\n", p)
+		case "fold":
+			fmt.Fprintf(&b, "%s: folds\n", p)
+		case "uncalled":
+			fmt.Fprintf(&b, "Uncalled bet (%d) returned to %s\n", a.Amount, p)
+			bets[a.PlayerId] -= a.Amount
+			stacks[a.PlayerId] += a.Amount
+		default: // call, bet, raise, allin
+			allIn := allInSuffix(stacks[a.PlayerId] == delta)
+			switch {
+			case a.Amount <= currentBet:
+				fmt.Fprintf(&b, "%s: calls %d%s\n", p, delta, allIn)
+			case currentBet == 0:
+				fmt.Fprintf(&b, "%s: bets %d%s\n", p, delta, allIn)
+			default:
+				fmt.Fprintf(&b, "%s: raises %d to %d%s\n", p, a.Amount-currentBet, a.Amount, allIn)
+			}
+			currentBet = max(currentBet, a.Amount)
+			bets[a.PlayerId] = a.Amount
+			stacks[a.PlayerId] -= delta
+		}
+	}
+	// улицы, на которых уже никто не мог ходить (все в all in)
+	if len(h.Boards) > 1 {
+		for i, board := range h.Boards {
+			openPokerStarsStreets(&b, h.GameType, pokerStarsRuns[i]+" ", board, opened, len(board))
+		}
+	} else {
+		openPokerStarsStreets(&b, h.GameType, "", h.Board, opened, len(h.Board))
+	}
+
+	// в порядке вскрытия. В раздачах, записанных без порядка, - по местам
+	order := h.ShowOrder
+	if len(order) == 0 {
+		for _, s := range h.Seats {
+			if s.Shown {
+				order = append(order, s.PlayerId)
+			}
+		}
+	}
+	shows := func() {
+		for _, k := range order {
+			switch s, _ := h.Seat(k); {
+			case s.Shown:
+				fmt.Fprintf(&b, "%s: shows %s\n", name(k), pokerStarsCards(s.Cards))
+			case s.Mucked:
+				fmt.Fprintf(&b, "%s: mucks hand\n", name(k))
+			}
+		}
+	}
+	if h.Showdown {
+		b.WriteString("*** SHOW DOWN ***\n")
+		shows()
+	}
+	for _, pot := range h.Pots {
+		where := "pot"
+		if len(h.Pots) > 1 && pot.Share == "" {
+			where = "main pot"
+			if pot.Pot > 1 {
+				where = fmt.Sprintf("side pot-%d", pot.Pot-1)
+			}
+		}
+		for _, w := range pot.Winners {
+			fmt.Fprintf(&b, "%s collected %d from %s\n", name(w), pot.Amount, where)
+		}
+	}
+	if !h.Showdown {
+		shows() // победитель открыл карты, забрав банк без вскрытия
+	}
+
+	b.WriteString("*** SUMMARY ***\n")
+	fmt.Fprintf(&b, "Total pot %d | Rake %d\n", h.Pot, h.Rake)
+	if len(h.Boards) > 1 {
+		fmt.Fprintf(&b, "Hand was run %s\n", pokerStarsTimes[len(h.Boards)])
+		for i, board := range h.Boards {
+			fmt.Fprintf(&b, "%s Board %s\n", pokerStarsRuns[i], pokerStarsCards(board))
+		}
+	} else if len(h.Board) != 0 {
+		fmt.Fprintf(&b, "Board %s\n", pokerStarsCards(h.Board))
+	}
+	for _, s := range h.Seats {
+		if s.SittingOut {
+			continue
+		}
+		fmt.Fprintf(&b, "Seat %d: %s%s %s\n", s.Seat, name(s.PlayerId), pokerStarsPosition(h, s.PlayerId), pokerStarsResult(s))
+	}
+	return b.String()
+}
+
+// streetBoardSize возвращает, сколько карт борда открыто на улице
+func streetBoardSize(gameType, street string) int {
+	size := 0
+	for _, st := range gameRulesFor(gameType).Streets() {
+		size += st.CommunityCards
+		if st.Name == street {
+			return size
+		}
+	}
+	return 0
+}
+
+// openPokerStarsStreets пишет заголовки улиц, пока открыто меньше upto карт борда, и возвращает, сколько карт открыто.
+// run - приставка прогона (FIRST, SECOND), если борд прогоняли несколько раз
+func openPokerStarsStreets(b *strings.Builder, gameType, run string, board []Card, opened, upto int) int {
+	size := 0
+	for _, st := range gameRulesFor(gameType).Streets() {
+		size += st.CommunityCards
+		if size <= opened || size > upto || size > len(board) {
+			continue
+		}
+		if opened == 0 {
+			fmt.Fprintf(b, "*** %s%s *** %s\n", run, strings.ToUpper(st.Name), pokerStarsCards(board[:size]))
+		} else {
+			fmt.Fprintf(b, "*** %s%s *** %s %s\n", run, strings.ToUpper(st.Name), pokerStarsCards(board[:opened]), pokerStarsCards(board[opened:size]))
+		}
+		opened = size
+	}
+	return opened
+}
+
+func allInSuffix(allIn bool) string {
+	if allIn {
+		return " and is all-in"
+	}
+	return ""
+}
+
+func pokerStarsPosition(h HandHistory, playerId string) string {
+	if playerId == h.Dealer {
+		return " (button)"
+	}
+	for _, blind := range h.Blinds {
+		if blind.PlayerId == playerId && (blind.Blind == BlindSmall || blind.Blind == BlindBig) {
+			return " (" + pokerStarsBlinds[blind.Blind] + ")"
+		}
+	}
+	return ""
+}
+
+func pokerStarsResult(s HistorySeat) string {
+	switch {
+	case s.Folded == StreetPreflop:
+		return "folded before Flop"
+	case s.Folded != "":
+		return "folded on the " + pokerStarsStreet(s.Folded)
+	case s.Shown && s.Won > 0:
+		return fmt.Sprintf("showed %s and won (%d)", pokerStarsCards(s.Cards), s.Won)
+	case s.Shown:
+		return fmt.Sprintf("showed %s and lost", pokerStarsCards(s.Cards))
+	case s.Won > 0:
+		return fmt.Sprintf("collected (%d)", s.Won)
+	}
+	return "mucked"
+}
@@ -1,38 +1,49 @@
 package holdem
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 type Pot struct {
 	Amount     int
 	Applicants []string
 }
 
+// CreatePots строит основной и побочные банки по суммарным вкладам игроков за раздачу.
+// Сбросившие игроки не претендуют на банки, но их фишки в банках остаются
 func CreatePots(players map[string]IPlayer) []Pot {
 	pots := []Pot{}
 
-	for {
-		minBet := -1
-		applicants := make([]string, 0, len(players))
-		for k, v := range players { // находим минимальную ставку
-			if v.GetLastBet() == 0 {
-				continue
-			}
-			applicants = append(applicants, k)
-			if minBet == -1 {
-				minBet = v.GetLastBet()
-			}
-			minBet = min(v.GetLastBet(), minBet)
+	levels := make([]int, 0, len(players))
+	for _, v := range players {
+		if v.GetFold() || v.GetTotalBet() == 0 || slices.Contains(levels, v.GetTotalBet()) {
+			continue
 		}
+		levels = append(levels, v.GetTotalBet())
+	}
+	slices.Sort(levels)
 
-		for _, k := range applicants {
-			v := players[k]
-			players[k].SetLastBet(v.GetLastBet() - minBet)
+	prevLevel := 0
+	for _, level := range levels {
+		pot := Pot{Applicants: make([]string, 0, len(players))}
+		for k, v := range players {
+			if v.GetTotalBet() > prevLevel {
+				pot.Amount += min(v.GetTotalBet(), level) - prevLevel
+			}
+			if !v.GetFold() && v.GetTotalBet() >= level {
+				pot.Applicants = append(pot.Applicants, k)
+			}
 		}
+		pots = append(pots, pot)
+		prevLevel = level
+	}
 
-		if minBet == -1 {
-			break
+	// фишки сбросивших игроков сверх вклада любого из оставшихся уходят в последний банк
+	for _, v := range players {
+		if v.GetTotalBet() > prevLevel && len(pots) != 0 {
+			pots[len(pots)-1].Amount += v.GetTotalBet() - prevLevel
 		}
-		pots = append(pots, Pot{Amount: len(applicants) * minBet, Applicants: applicants})
 	}
 	return pots
 }
@@ -21,16 +21,16 @@ func TestPot(t *testing.T) {
 			TestCaseName: "One pot",
 			Data: map[string]IPlayer{
 				"1": &Player{
-					LastBet: 500,
-					IsFold:  false,
+					TotalBet: 500,
+					IsFold:   false,
 				},
 				"2": &Player{
-					LastBet: 500,
-					IsFold:  false,
+					TotalBet: 500,
+					IsFold:   false,
 				},
 				"3": &Player{
-					LastBet: 500,
-					IsFold:  false,
+					TotalBet: 500,
+					IsFold:   false,
 				},
 			},
 			Expected: []Pot{
@@ -41,32 +41,32 @@ func TestPot(t *testing.T) {
 			TestCaseName: "One pot with fold",
 			Data: map[string]IPlayer{
 				"1": &Player{
-					LastBet: 500,
-					IsFold:  false,
+					TotalBet: 500,
+					IsFold:   false,
 				},
 				"2": &Player{
-					LastBet: 500,
-					IsFold:  false,
+					TotalBet: 500,
+					IsFold:   false,
 				},
 				"3": &Player{
-					LastBet: 500,
-					IsFold:  true,
+					TotalBet: 500,
+					IsFold:   true,
 				},
 			},
 			Expected: []Pot{
-				Pot{Amount: 1000, Applicants: []string{"1", "2"}},
+				Pot{Amount: 1500, Applicants: []string{"1", "2"}},
 			},
 		},
 		{
 			TestCaseName: "two pots",
 			Data: map[string]IPlayer{
 				"1": &Player{
-					LastBet: 500,
-					IsFold:  false,
+					TotalBet: 500,
+					IsFold:   false,
 				},
 				"2": &Player{
-					LastBet: 400,
-					IsFold:  false,
+					TotalBet: 400,
+					IsFold:   false,
 				},
 			},
 			Expected: []Pot{
@@ -78,16 +78,16 @@ func TestPot(t *testing.T) {
 			TestCaseName: "several pots",
 			Data: map[string]IPlayer{
 				"1": &Player{
-					LastBet: 500,
-					IsFold:  false,
+					TotalBet: 500,
+					IsFold:   false,
 				},
 				"2": &Player{
-					LastBet: 400,
-					IsFold:  false,
+					TotalBet: 400,
+					IsFold:   false,
 				},
 				"3": &Player{
-					LastBet: 300,
-					IsFold:  false,
+					TotalBet: 300,
+					IsFold:   false,
 				},
 			},
 			Expected: []Pot{
@@ -100,21 +100,21 @@ func TestPot(t *testing.T) {
 			TestCaseName: "several pots with fold",
 			Data: map[string]IPlayer{
 				"1": &Player{
-					LastBet: 500,
-					IsFold:  true,
+					TotalBet: 500,
+					IsFold:   true,
 				},
 				"2": &Player{
-					LastBet: 400,
-					IsFold:  false,
+					TotalBet: 400,
+					IsFold:   false,
 				},
 				"3": &Player{
-					LastBet: 300,
-					IsFold:  false,
+					TotalBet: 300,
+					IsFold:   false,
 				},
 			},
 			Expected: []Pot{
-				Pot{Amount: 600, Applicants: []string{"2", "3"}},
-				Pot{Amount: 100, Applicants: []string{"2"}},
+				Pot{Amount: 900, Applicants: []string{"2", "3"}},
+				Pot{Amount: 300, Applicants: []string{"2"}},
 			},
 		},
 	}
@@ -123,6 +123,7 @@ func TestPot(t *testing.T) {
 		t.Run(tCase.TestCaseName,
 			func(t *testing.T) {
 				res := CreatePots(tCase.Data)
+				require.Len(t, res, len(tCase.Expected))
 				for k, _ := range res {
 					require.ElementsMatch(t, res[k].Applicants, tCase.Expected[k].Applicants)
 					require.Equal(t, res[k].Amount, tCase.Expected[k].Amount)
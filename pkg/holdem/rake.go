@@ -0,0 +1,52 @@
+package holdem
+
+import (
+	"fmt"
+	"math"
+)
+
+const MaxRakePercent = 10
+
+var ErrBadRake = fmt.Errorf("rake percent must be from 0 to %d and rake cap cant be negative", MaxRakePercent)
+
+// SetRake задает рейк заведения: процент с банка, максимум с одной раздачи (0 - без ограничения)
+// и no flop no drop - с раздач, закончившихся до флопа, рейк не берется
+func (cfg *TableConfig) SetRake(percent float64, cap int, noFlopNoDrop bool) error {
+	if !(percent >= 0 && percent <= MaxRakePercent) || cap < 0 {
+		return ErrBadRake
+	}
+	cfg.RakePercent = percent
+	cfg.RakeCap = cap
+	cfg.NoFlopNoDrop = noFlopNoDrop
+	return nil
+}
+
+// markFlop запоминает, дошла ли раздача до флопа хотя бы вдвоем
+func (t *PokerTable) markFlop() {
+	inHand := 0
+	for _, p := range t.Meta.Players {
+		if !p.GetFold() {
+			inHand++
+		}
+	}
+	t.Meta.Flopped = inHand > 1
+}
+
+// potsRake считает рейк с каждого банка по порядку, начиная с основного, пока не набран лимит раздачи.
+// Процент переводится в сотые доли, чтобы считать в целых числах
+func (t *PokerTable) potsRake() []int {
+	rake := make([]int, len(t.Meta.Pots))
+	basisPoints := int(math.Round(t.Config.RakePercent * 100))
+	if basisPoints == 0 || (t.Config.NoFlopNoDrop && !t.Meta.Flopped) {
+		return rake
+	}
+	total := 0
+	for i, pot := range t.Meta.Pots {
+		rake[i] = pot.Amount * basisPoints / 10000
+		if t.Config.RakeCap > 0 {
+			rake[i] = min(rake[i], t.Config.RakeCap-total)
+		}
+		total += rake[i]
+	}
+	return rake
+}
@@ -0,0 +1,91 @@
+package holdem
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRake(t *testing.T) {
+	testCases := []struct {
+		name         string
+		percent      float64
+		cap          int
+		noFlopNoDrop bool
+		fold         bool
+		rake         int
+	}{
+		{"showdown", 5, 0, true, false, 15},
+		{"capped", 10, 20, true, false, 20},
+		{"fractional percent", 2.5, 0, false, false, 7},
+		{"no flop no drop", 5, 0, true, true, 0},
+		{"no flop with drop", 5, 0, false, true, 5},
+		{"without rake", 0, 0, false, false, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+			require.NoError(t, config.SetRake(tc.percent, tc.cap, tc.noFlopNoDrop))
+			table := NewPokerTable(config)
+			rec := &eventRecorder{}
+			hands := &[]HandHistory{}
+			table.AddObserver(rec)
+			table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+			p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+			p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+			p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+			table.AddPlayer(p1)
+			table.AddPlayer(p2)
+			table.AddPlayer(p3)
+			table.StartGame()
+			if tc.fold {
+				require.NoError(t, table.MakeMove(p2.GetId(), "fold", 0))
+				require.NoError(t, table.MakeMove(p3.GetId(), "fold", 0))
+				require.Equal(t, rec.byType(EventWinAll), []any{AllPotsWon{PlayerId: p1.GetId(), Amount: 100 - tc.rake, Rake: tc.rake}})
+			} else {
+				require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+				require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+				for table.Meta.GameStarted {
+					require.NoError(t, table.MakeMove(table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], "check", 0))
+				}
+			}
+
+			// рейк уходит из игры, в событиях выплат он виден по частям банка
+			require.Equal(t, p1.GetBalance()+p2.GetBalance()+p3.GetBalance(), 3000-tc.rake)
+			rake := 0
+			for _, e := range rec.byType(EventWinPot) {
+				rake += e.(PotAwarded).Rake
+			}
+			for _, e := range rec.byType(EventWinAll) {
+				rake += e.(AllPotsWon).Rake
+			}
+			require.Equal(t, rake, tc.rake)
+
+			require.Len(t, *hands, 1)
+			h := (*hands)[0]
+			require.Equal(t, h.Rake, tc.rake)
+			won := 0
+			for _, s := range h.Seats {
+				won += s.Won
+			}
+			require.Equal(t, won, h.Pot-tc.rake)
+			res, err := ReplayHand(h)
+			require.NoError(t, err)
+			require.True(t, res.Consistent)
+			require.Equal(t, res.Hand.Rake, tc.rake)
+		})
+	}
+}
+
+func TestSetRake(t *testing.T) {
+	cfg := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1)
+	require.ErrorIs(t, cfg.SetRake(-1, 0, false), ErrBadRake)
+	require.ErrorIs(t, cfg.SetRake(MaxRakePercent+1, 0, false), ErrBadRake)
+	require.ErrorIs(t, cfg.SetRake(math.NaN(), 0, false), ErrBadRake)
+	require.ErrorIs(t, cfg.SetRake(5, -1, false), ErrBadRake)
+	require.NoError(t, cfg.SetRake(MaxRakePercent, 300, true))
+	require.Equal(t, cfg.RakeCap, 300)
+}
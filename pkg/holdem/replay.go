@@ -0,0 +1,194 @@
+package holdem
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNoReplayLog      = errors.New("hand has no replay log")
+	ErrReplayIncomplete = errors.New("replayed hand did not finish")
+)
+
+// ReplayEvent - сообщение стола при повторе раздачи. Offset - секунды от начала раздачи,
+// когда в оригинальной раздаче был сделан ход, вызвавший сообщение
+type ReplayEvent struct {
+	Offset     float64         `json:"offset"`
+	Recipients []string        `json:"-"`
+	Message    ObserverMessage `json:"message"`
+}
+
+// ReplayResult - повтор раздачи. Consistent - совпали ли карты, борд и выигрыши с записанной раздачей
+// @Schema
+type ReplayResult struct {
+	Events     []ReplayEvent `json:"events"`
+	Hand       HandHistory   `json:"hand"`
+	Consistent bool          `json:"consistent"`
+}
+
+// ForPlayer оставляет только сообщения, которые получал игрок, и скрывает чужие карты
+func (r ReplayResult) ForPlayer(playerId string) ReplayResult {
+	events := make([]ReplayEvent, 0, len(r.Events))
+	for _, e := range r.Events {
+		if slices.Contains(e.Recipients, playerId) {
+			events = append(events, e)
+		}
+	}
+	r.Events = events
+	r.Hand = r.Hand.ForPlayer(playerId)
+	return r
+}
+
+type replayObserver struct {
+	offset float64
+	events []ReplayEvent
+}
+
+func (o *replayObserver) Update(recipients []string, data ObserverMessage) {
+	if data.EventType == EventShuffleCommit { // сид следующей раздачи при повторе новый, к раздаче он не относится
+		return
+	}
+	o.events = append(o.events, ReplayEvent{Offset: o.offset, Recipients: slices.Clone(recipients), Message: data})
+}
+
+// replayShuffler раскладывает записанную колоду и повторяет раскрытие сида, если перемешивание было проверяемым
+type replayShuffler struct {
+	deck  *ScriptedShuffler
+	proof *ShuffleProof
+}
+
+func (s *replayShuffler) Commit() string {
+	if s.proof == nil {
+		return ""
+	}
+	return s.proof.Commitment
+}
+
+func (s *replayShuffler) Shuffle(deck []Card, clientSeeds []ClientSeed) *ShuffleProof {
+	s.deck.Shuffle(deck, clientSeeds)
+	proof := s.proof
+	s.proof = nil
+	if proof == nil {
+		return nil
+	}
+	p := *proof
+	return &p
+}
+
+// ReplayHand заново разыгрывает записанную раздачу на новом столе: та же рассадка, стеки, блайнды и колода,
+// затем по порядку все попытки ходов. Время хода и банк времени не повторяются, ходы по таймеру делаются сразу
+func ReplayHand(h HandHistory) (ReplayResult, error) {
+	if h.Replay == nil || len(h.Replay.Deck) == 0 {
+		return ReplayResult{}, ErrNoReplayLog
+	}
+	tableId, err := uuid.Parse(h.TableId)
+	if err != nil {
+		return ReplayResult{}, err
+	}
+	cfg := NewTableConfig(0, h.MaxPlayers, 2, h.SmallBlind, h.Ante, 0, false, 0)
+	cfg.Shuffler = &replayShuffler{deck: NewScriptedShuffler(h.Replay.Deck), proof: h.Shuffle}
+	cfg.TableId = tableId
+	cfg.ButtonBlind = h.Replay.ButtonBlind
+	cfg.RunItMax = h.Replay.RunItMax
+	cfg.Straddle = h.Replay.Straddle
+	cfg.BombPotAnte = h.Replay.BombPot
+	cfg.RakePercent = h.Replay.RakePercent
+	cfg.RakeCap = h.Replay.RakeCap
+	cfg.NoFlopNoDrop = h.Replay.NoFlopNoDrop
+	if err := cfg.SetGameType(h.GameType); err != nil {
+		return ReplayResult{}, err
+	}
+	if err := cfg.SetBettingStructure(h.BettingStructure, h.Replay.RaiseCap); err != nil {
+		return ReplayResult{}, err
+	}
+
+	t := NewPokerTable(cfg)
+	t.Meta.ButtonSeat = h.Replay.DealerIndex
+	if len(h.Replay.BlindSeats) == 2 {
+		t.Meta.SmallBlindSeat, t.Meta.BigBlindSeat = h.Replay.BlindSeats[0], h.Replay.BlindSeats[1]
+	}
+	t.Meta.HandCount = h.Hand - 1
+	t.Meta.BombPotNext = h.Replay.BombPot > 0
+	for _, s := range h.Seats {
+		id, err := uuid.Parse(s.PlayerId)
+		if err != nil {
+			return ReplayResult{}, err
+		}
+		t.Meta.addPlayerInGame(&Player{Id: id, Balance: s.Stack}, 0)
+		t.Meta.PlayersOrder = append(t.Meta.PlayersOrder, s.PlayerId)
+		t.Meta.Seats[s.PlayerId] = s.Seat - 1
+		t.Config.CurrentPlayers++
+		if s.SittingOut {
+			t.Meta.SittingOut[s.PlayerId] = &SitOut{}
+		}
+	}
+	for _, k := range h.Replay.DeadBlinds {
+		t.Meta.SittingOut[k] = &SitOut{Return: sitOutNextHand, MissedBlind: true, PostMissed: true}
+	}
+
+	var replayed *HandHistory
+	obs := &replayObserver{}
+	t.AddObserver(obs)
+	t.AddObserver(NewHandRecorder(func(rh HandHistory) { replayed = &rh }))
+	if err := t.StartGame(); err != nil {
+		return ReplayResult{}, err
+	}
+	for _, m := range h.Replay.Moves {
+		obs.offset = m.Offset
+		if m.Action == ActionRunIt {
+			t.mu.Lock()
+			t.runItVote(m.PlayerId, m.Amount, m.Timeout)
+			t.mu.Unlock()
+			continue
+		}
+		if m.Action == ActionShow {
+			t.ShowCards(m.PlayerId)
+			continue
+		}
+		if m.Timeout {
+			t.mu.Lock()
+			t.timeoutMove(m.PlayerId)
+			t.mu.Unlock()
+			continue
+		}
+		t.MakeMove(m.PlayerId, m.Action, m.Amount) // отклоненные ходы отклоняются и при повторе
+	}
+	if replayed == nil {
+		return ReplayResult{Events: obs.events}, ErrReplayIncomplete
+	}
+	replayed.Id = h.Id
+	replayed.StartedAt, replayed.FinishedAt = h.StartedAt, h.FinishedAt
+	return ReplayResult{
+		Events:     obs.events,
+		Hand:       *replayed,
+		Consistent: sameResult(h, *replayed),
+	}, nil
+}
+
+// replayable - попытки ходов, которые повторяет ReplayHand. Остальные просьбы игрока на раздачу не влияют
+func replayable(action string) bool {
+	switch action {
+	case ActionSitOut, ActionSitIn, ActionBombPot, ActionClientSeed:
+		return false
+	}
+	return true
+}
+
+// sameResult сравнивает карты, открытые карты, борд и выигрыши двух записей раздачи
+func sameResult(a, b HandHistory) bool {
+	if len(a.Seats) != len(b.Seats) || !slices.Equal(a.Board, b.Board) || a.Pot != b.Pot {
+		return false
+	}
+	if !slices.EqualFunc(a.Boards, b.Boards, func(x, y []Card) bool { return slices.Equal(x, y) }) {
+		return false
+	}
+	for i := range a.Seats {
+		sa, sb := a.Seats[i], b.Seats[i]
+		if sa.PlayerId != sb.PlayerId || sa.Won != sb.Won || sa.Shown != sb.Shown || !slices.Equal(sa.Cards, sb.Cards) {
+			return false
+		}
+	}
+	return true
+}
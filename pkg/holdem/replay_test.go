@@ -0,0 +1,95 @@
+package holdem
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// requireSameMessages проверяет, что повтор разослал те же сообщения, что и стол, кроме таймеров хода
+func requireSameMessages(t *testing.T, res ReplayResult, messages []ObserverMessage) {
+	original := []ObserverMessage{}
+	for _, m := range messages {
+		switch m.EventType {
+		case EventActionClock, EventTimeBank, EventPlayerEnter, EventShuffleCommit:
+			continue
+		}
+		original = append(original, m)
+	}
+	replayed := []ObserverMessage{}
+	for _, e := range res.Events {
+		replayed = append(replayed, e.Message)
+	}
+	require.Equal(t, replayed, original)
+}
+
+func TestReplayHand(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 10, 0, false, 1488)
+	config.MoveTimeout = time.Hour
+	config.Shuffler = NewCryptoShuffler()
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	hands := &[]HandHistory{}
+	table.AddObserver(rec)
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+
+	require.ErrorIs(t, table.MakeMove(p2.GetId(), "raise", 150), ErrCantRaise)
+	require.NoError(t, table.MakeMove(p2.GetId(), "raise", 300))
+	require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+	// большой блайнд не успевает походить и сбрасывает по таймеру
+	table.onClockExpired(p1.GetId(), table.Meta.TurnId)
+	require.True(t, p1.GetFold())
+	require.NoError(t, table.MakeMove(p3.GetId(), "check", 0))
+	require.NoError(t, table.MakeMove(p2.GetId(), "bet", 200))
+	require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+	for i := 0; i < 2; i++ {
+		require.NoError(t, table.MakeMove(p3.GetId(), "check", 0))
+		require.NoError(t, table.MakeMove(p2.GetId(), "check", 0))
+	}
+	require.Len(t, *hands, 1)
+	h := (*hands)[0]
+	require.NotNil(t, h.Replay)
+	require.Len(t, h.Replay.Deck, 52)
+	require.NotNil(t, h.Shuffle)
+	require.Len(t, h.Replay.Moves, 11)
+	require.Equal(t, h.Replay.Moves[3], LoggedMove{PlayerId: p1.GetId(), Action: "fold", Timeout: true, Offset: h.Replay.Moves[3].Offset})
+
+	res, err := ReplayHand(h)
+	require.NoError(t, err)
+	require.True(t, res.Consistent)
+
+	requireSameMessages(t, res, rec.messages)
+	require.True(t, slices.IsSortedFunc(res.Events, func(a, b ReplayEvent) int {
+		return int((a.Offset - b.Offset) * 1e6)
+	}))
+
+	// игрок видит только то, что получал за столом
+	forP1 := res.ForPlayer(p1.GetId())
+	require.Nil(t, forP1.Hand.Replay)
+	for _, e := range forP1.Events {
+		require.NotEqual(t, e.Message.EventType, EventReplayState)
+		if cards, ok := e.Message.EventData.(CardsDealt); ok {
+			require.Equal(t, cards.PlayerId, p1.GetId())
+		}
+	}
+
+	tampered := h
+	tampered.Seats = slices.Clone(h.Seats)
+	tampered.Seats[0].Won += 100
+	res, err = ReplayHand(tampered)
+	require.NoError(t, err)
+	require.False(t, res.Consistent)
+
+	_, err = ReplayHand(h.ForPlayer(p1.GetId()))
+	require.ErrorIs(t, err, ErrNoReplayLog)
+}
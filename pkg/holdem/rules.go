@@ -0,0 +1,102 @@
+package holdem
+
+import "errors"
+
+var (
+	ErrUnknownGameType = errors.New("unknown game type")
+)
+
+const (
+	GameHoldem = "holdem"
+)
+
+// IGameRules - правила разновидности покера. Стол, наблюдатели и лобби от разновидности не зависят,
+// все отличия (колода, раздача по улицам, оценка рук и дележ банка) описываются здесь
+type IGameRules interface {
+	Name() string
+	Deck() []Card
+	HoleCards() int
+	// Streets - улицы после префлопа по порядку. После последней улицы наступает вскрытие
+	Streets() []Street
+	Evaluate(playerHand []Card, communityCards []Card) Combination
+	// DefaultBettingStructure - структура ставок, если при создании стола она не указана
+	DefaultBettingStructure() string
+	// Showdown делит банк между претендентами. Банк делится поровну между долями, нечетные фишки уходят первым долям
+	Showdown(communityCards []Card, applicants map[string]IPlayer) []PotShare
+}
+
+// Street - улица торговли и сколько общих карт на ней открывается
+type Street struct {
+	Name           string
+	CommunityCards int
+}
+
+// PotShare - доля банка и ее победители. Name пустое, если банк не делится на части (в хай-лоу это high и low)
+type PotShare struct {
+	Name    string
+	Winners []string
+}
+
+var holdemStreets = []Street{{"flop", 3}, {"turn", 1}, {"river", 1}}
+
+var gameRules = map[string]IGameRules{}
+
+func init() {
+	RegisterGameRules(holdemRules{})
+	RegisterGameRules(omahaRules{})
+	RegisterGameRules(omahaHiLoRules{})
+	RegisterGameRules(shortDeckRules{})
+}
+
+// RegisterGameRules добавляет разновидность покера, после чего ее можно указать в game_type.
+// Вызывать при инициализации, до создания столов
+func RegisterGameRules(rules IGameRules) {
+	gameRules[rules.Name()] = rules
+}
+
+// SetGameType задает разновидность покера. Пустая строка - холдем
+func (cfg *TableConfig) SetGameType(gameType string) error {
+	if gameType == "" {
+		gameType = GameHoldem
+	}
+	if _, ok := gameRules[gameType]; !ok {
+		return ErrUnknownGameType
+	}
+	cfg.GameType = gameType
+	return nil
+}
+
+// Rules возвращает правила игры стола. Для неизвестного типа - холдем
+func (cfg *TableConfig) Rules() IGameRules {
+	return gameRulesFor(cfg.GameType)
+}
+
+// gameRulesFor возвращает правила игры по названию, для неизвестных - холдем
+func gameRulesFor(gameType string) IGameRules {
+	if rules, ok := gameRules[gameType]; ok {
+		return rules
+	}
+	return gameRules[GameHoldem]
+}
+
+// highShowdown - обычное вскрытие, весь банк забирает старшая рука
+func highShowdown(evaluate HandEvaluator, communityCards []Card, applicants map[string]IPlayer) []PotShare {
+	winners, _ := DeterminateWinnerBy(evaluate, communityCards, applicants)
+	return []PotShare{{Winners: winners}}
+}
+
+type holdemRules struct{}
+
+func (holdemRules) Name() string                    { return GameHoldem }
+func (holdemRules) Deck() []Card                    { return GetStandardDeck() }
+func (holdemRules) HoleCards() int                  { return 2 }
+func (holdemRules) Streets() []Street               { return holdemStreets }
+func (holdemRules) DefaultBettingStructure() string { return BettingNoLimit }
+
+func (holdemRules) Evaluate(playerHand []Card, communityCards []Card) Combination {
+	return EvaluateHand(playerHand, communityCards)
+}
+
+func (r holdemRules) Showdown(communityCards []Card, applicants map[string]IPlayer) []PotShare {
+	return highShowdown(r.Evaluate, communityCards, applicants)
+}
@@ -0,0 +1,97 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameRules(t *testing.T) {
+	cases := []struct {
+		TestCaseName             string
+		GameType                 string
+		ExpectedGameType         string
+		ExpectedHoleCards        int
+		ExpectedDeckLen          int
+		ExpectedBettingStructure string
+		ExpectedErr              error
+	}{
+		{
+			TestCaseName:             "Default is holdem",
+			GameType:                 "",
+			ExpectedGameType:         GameHoldem,
+			ExpectedHoleCards:        2,
+			ExpectedDeckLen:          52,
+			ExpectedBettingStructure: BettingNoLimit,
+		},
+		{
+			TestCaseName:             "Omaha hi-lo",
+			GameType:                 GameOmahaHiLo,
+			ExpectedGameType:         GameOmahaHiLo,
+			ExpectedHoleCards:        4,
+			ExpectedDeckLen:          52,
+			ExpectedBettingStructure: BettingPotLimit,
+		},
+		{
+			TestCaseName:             "Short deck",
+			GameType:                 GameShortDeck,
+			ExpectedGameType:         GameShortDeck,
+			ExpectedHoleCards:        2,
+			ExpectedDeckLen:          36,
+			ExpectedBettingStructure: BettingNoLimit,
+		},
+		{
+			TestCaseName:             "Unknown game",
+			GameType:                 "razz",
+			ExpectedGameType:         GameHoldem,
+			ExpectedHoleCards:        2,
+			ExpectedDeckLen:          52,
+			ExpectedBettingStructure: BettingNoLimit,
+			ExpectedErr:              ErrUnknownGameType,
+		},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+			require.ErrorIs(t, config.SetGameType(tCase.GameType), tCase.ExpectedErr)
+			require.NoError(t, config.SetBettingStructure("", 0))
+			rules := config.Rules()
+			require.Equal(t, rules.Name(), tCase.ExpectedGameType)
+			require.Equal(t, rules.HoleCards(), tCase.ExpectedHoleCards)
+			require.Len(t, rules.Deck(), tCase.ExpectedDeckLen)
+			require.Equal(t, config.BettingStructure, tCase.ExpectedBettingStructure)
+		})
+	}
+}
+
+// threeCardRules - холдем с тремя картами в руке, чтобы проверить подключение новой разновидности
+type threeCardRules struct {
+	holdemRules
+}
+
+func (threeCardRules) Name() string   { return "three_card_holdem" }
+func (threeCardRules) HoleCards() int { return 3 }
+
+func TestRegisterGameRules(t *testing.T) {
+	RegisterGameRules(threeCardRules{})
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	require.NoError(t, config.SetGameType("three_card_holdem"))
+	table := NewPokerTable(config)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.StartGame()
+
+	require.Len(t, p1.Hand.Cards, 3)
+	require.NoError(t, table.MakeMove(p1.GetId(), "call", 0))
+	require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, table.MakeMove(p1.GetId(), "check", 0))
+		require.NoError(t, table.MakeMove(p2.GetId(), "check", 0))
+	}
+	require.Equal(t, table.Meta.GameStarted, false)
+	require.Equal(t, p1.Balance+p2.Balance, 2000)
+}
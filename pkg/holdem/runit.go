@@ -0,0 +1,168 @@
+package holdem
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	MaxRunItTimes = 3
+	ActionRunIt   = "run_it" // голос игрока за количество прогонов вместо хода
+)
+
+var (
+	ErrBadRunItMax   = fmt.Errorf("run it max must be from 0 to %d", MaxRunItTimes)
+	ErrNoRunItOffer  = errors.New("there is no run it offer now")
+	ErrRunItVoting   = errors.New("waiting for run it votes")
+	ErrNotInRunIt    = errors.New("player does not take part in the run it offer")
+	ErrAlreadyVoted  = errors.New("player already voted")
+	ErrBadRunItTimes = errors.New("run it times out of range")
+)
+
+// RunIt - предложение прогнать оставшийся борд несколько раз, когда торговли больше не будет
+type RunIt struct {
+	Players  []string
+	MaxRuns  int
+	Votes    map[string]int
+	Runs     int // 0, пока не проголосовали все
+	Deadline time.Time
+}
+
+// SetRunItMax задает, сколько раз игроки могут договориться прогнать борд. 0 и 1 - не предлагать
+func (cfg *TableConfig) SetRunItMax(runs int) error {
+	if runs < 0 || runs > MaxRunItTimes {
+		return ErrBadRunItMax
+	}
+	cfg.RunItMax = runs
+	return nil
+}
+
+// runItPending показывает, что стол ждет голосов за количество прогонов
+func (t *PokerTable) runItPending() bool {
+	return t.Meta.RunIt != nil && t.Meta.RunIt.Runs == 0
+}
+
+// offerRunIt предлагает прогнать борд несколько раз, если до ривера торговля закончилась, а карты не сбросили двое и больше.
+// Возвращает true, если раздача ждет голосов
+func (t *PokerTable) offerRunIt() bool {
+	if t.Config.RunItMax < 2 || t.Meta.RunIt != nil || t.Meta.CurrentRound < 0 {
+		return false
+	}
+	need := 0
+	for _, st := range t.Config.Rules().Streets()[min(t.Meta.CurrentRound, len(t.Config.Rules().Streets())):] {
+		need += st.CommunityCards
+	}
+	if need == 0 {
+		return false
+	}
+	players := []string{}
+	withChips := 0
+	for _, k := range t.Meta.PlayersOrder {
+		p := t.Meta.Players[k]
+		if p.GetFold() {
+			continue
+		}
+		players = append(players, k)
+		if p.GetBalance() != 0 {
+			withChips++
+		}
+	}
+	maxRuns := min(t.Config.RunItMax, len(t.Meta.Deck)/need)
+	if len(players) < 2 || withChips > 1 || maxRuns < 2 {
+		return false
+	}
+	t.Meta.RunIt = &RunIt{Players: players, MaxRuns: maxRuns, Votes: make(map[string]int)}
+	t.stopClock()
+	if t.Config.MoveTimeout > 0 {
+		t.Meta.TurnId++
+		turnId := t.Meta.TurnId
+		t.Meta.RunIt.Deadline = time.Now().Add(t.Config.MoveTimeout)
+		t.clock = time.AfterFunc(t.Config.MoveTimeout, func() { t.onRunItExpired(turnId) })
+	}
+	t.notify(t.public(), EventRunItOffer, RunItOffer{Players: players, MaxRuns: maxRuns, Deadline: t.Meta.RunIt.Deadline})
+	return true
+}
+
+// RunItVote - голос игрока за количество прогонов. Борд прогоняется столько раз, сколько выбрал проголосовавший за меньшее
+func (t *PokerTable) RunItVote(playerId string, runs int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.runItVote(playerId, runs, false)
+}
+
+func (t *PokerTable) runItVote(playerId string, runs int, timeout bool) error {
+	err := t.checkRunItVote(playerId, runs)
+	if err != nil {
+		t.notify([]string{playerId}, EventBadMove, newBadMove(playerId, ActionRunIt, runs, err))
+		return err
+	}
+	offer := t.Meta.RunIt
+	offer.Votes[playerId] = runs
+	t.notify(t.public(), EventRunItVote, RunItVoted{PlayerId: playerId, Runs: runs, Timeout: timeout})
+	if len(offer.Votes) < len(offer.Players) {
+		return nil
+	}
+	t.stopClock()
+	offer.Runs = offer.MaxRuns
+	for _, v := range offer.Votes {
+		offer.Runs = min(offer.Runs, v)
+	}
+	if offer.Runs > 1 {
+		t.Meta.Boards = make([][]Card, offer.Runs)
+		for i := range t.Meta.Boards {
+			t.Meta.Boards[i] = slices.Clone(t.Meta.CommunityCards)
+		}
+	}
+	t.notify(t.public(), EventRunItAgreed, RunItAgreed{Runs: offer.Runs})
+	t.NewRound()
+	return nil
+}
+
+func (t *PokerTable) checkRunItVote(playerId string, runs int) error {
+	if !t.runItPending() {
+		return ErrNoRunItOffer
+	}
+	if !slices.Contains(t.Meta.RunIt.Players, playerId) {
+		return ErrNotInRunIt
+	}
+	if _, ok := t.Meta.RunIt.Votes[playerId]; ok {
+		return ErrAlreadyVoted
+	}
+	if runs < 1 || runs > t.Meta.RunIt.MaxRuns {
+		return &BetRangeError{Err: ErrBadRunItTimes, Min: 1, Max: t.Meta.RunIt.MaxRuns}
+	}
+	return nil
+}
+
+// onRunItExpired - не успевшие проголосовать соглашаются только на один прогон
+func (t *PokerTable) onRunItExpired(turnId int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.runItPending() || t.Meta.TurnId != turnId {
+		return
+	}
+	t.clock = nil
+	for _, k := range t.Meta.RunIt.Players {
+		if _, ok := t.Meta.RunIt.Votes[k]; !ok && t.runItPending() {
+			t.runItVote(k, 1, true)
+		}
+	}
+}
+
+// dealStreet открывает карты улицы на каждом борде
+func (t *PokerTable) dealStreet(street Street) {
+	if len(t.Meta.Boards) == 0 {
+		cards, _ := t.drawCard(street.CommunityCards)
+		t.Meta.CommunityCards = append(t.Meta.CommunityCards, cards...)
+		t.notify(t.public(), EventCommunityCards, CommunityCardsDealt{Street: street.Name, Cards: cards, Board: t.Meta.CommunityCards})
+		return
+	}
+	for i := range t.Meta.Boards {
+		cards, _ := t.drawCard(street.CommunityCards)
+		t.Meta.Boards[i] = append(t.Meta.Boards[i], cards...)
+		t.notify(t.public(), EventCommunityCards, CommunityCardsDealt{Street: street.Name, Cards: cards, Board: t.Meta.Boards[i], Run: i + 1})
+	}
+	t.Meta.CommunityCards = t.Meta.Boards[0]
+}
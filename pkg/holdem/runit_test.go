@@ -0,0 +1,125 @@
+package holdem
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// newAllInTable - p2 и p3 идут в all in на префлопе, p1 сбрасывает, стол ждет голосов за прогоны
+func newAllInTable(t *testing.T, moveTimeout time.Duration) (*PokerTable, *eventRecorder, *[]HandHistory, *Player, *Player, *Player) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	config.MoveTimeout = moveTimeout
+	require.NoError(t, config.SetRunItMax(2))
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	hands := &[]HandHistory{}
+	table.AddObserver(rec)
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+	require.NoError(t, table.MakeMove(p2.GetId(), "allin", 0))
+	require.NoError(t, table.MakeMove(p3.GetId(), "allin", 0))
+	require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+	return table, rec, hands, p1, p2, p3
+}
+
+func TestRunItTwice(t *testing.T) {
+	table, rec, hands, p1, p2, p3 := newAllInTable(t, 0)
+	require.Equal(t, rec.byType(EventRunItOffer), []any{RunItOffer{Players: []string{p2.GetId(), p3.GetId()}, MaxRuns: 2}})
+	require.Empty(t, rec.byType(EventCommunityCards))
+	require.True(t, table.Meta.GameStarted)
+
+	require.ErrorIs(t, table.MakeMove(p2.GetId(), "check", 0), ErrRunItVoting)
+	require.ErrorIs(t, table.RunItVote(p1.GetId(), 2), ErrNotInRunIt)
+	var rangeErr *BetRangeError
+	require.ErrorAs(t, table.RunItVote(p2.GetId(), 3), &rangeErr)
+	require.Equal(t, rangeErr.Max, 2)
+	require.NoError(t, table.RunItVote(p2.GetId(), 2))
+	require.ErrorIs(t, table.RunItVote(p2.GetId(), 2), ErrAlreadyVoted)
+	require.NoError(t, table.RunItVote(p3.GetId(), 2))
+	require.ErrorIs(t, table.RunItVote(p3.GetId(), 2), ErrNoRunItOffer)
+
+	require.False(t, table.Meta.GameStarted)
+	require.Equal(t, rec.byType(EventRunItAgreed), []any{RunItAgreed{Runs: 2}})
+	require.Len(t, rec.byType(EventCommunityCards), 6)
+
+	// каждый прогон разыгрывает половину банка
+	pot := 0
+	runs := []int{}
+	for _, e := range rec.byType(EventWinPot) {
+		award := e.(PotAwarded)
+		pot += award.Amount * len(award.Winners)
+		runs = append(runs, award.Run)
+	}
+	require.Equal(t, pot, 2100)
+	require.Equal(t, slices.Compact(runs), []int{1, 2})
+	require.Equal(t, p1.GetBalance()+p2.GetBalance()+p3.GetBalance(), 3000)
+
+	require.Len(t, *hands, 1)
+	h := (*hands)[0]
+	require.Len(t, h.Boards, 2)
+	require.Equal(t, h.Board, h.Boards[0])
+	for _, c := range h.Boards[1] {
+		require.NotContains(t, h.Boards[0], c)
+	}
+	require.Equal(t, h.Replay.Moves[3:], []LoggedMove{
+		{PlayerId: p2.GetId(), Action: "check", Offset: h.Replay.Moves[3].Offset},
+		{PlayerId: p1.GetId(), Action: ActionRunIt, Amount: 2, Offset: h.Replay.Moves[4].Offset},
+		{PlayerId: p2.GetId(), Action: ActionRunIt, Amount: 3, Offset: h.Replay.Moves[5].Offset},
+		{PlayerId: p2.GetId(), Action: ActionRunIt, Amount: 2, Offset: h.Replay.Moves[6].Offset},
+		{PlayerId: p2.GetId(), Action: ActionRunIt, Amount: 2, Offset: h.Replay.Moves[7].Offset},
+		{PlayerId: p3.GetId(), Action: ActionRunIt, Amount: 2, Offset: h.Replay.Moves[8].Offset},
+	})
+
+	res, err := ReplayHand(h)
+	require.NoError(t, err)
+	require.True(t, res.Consistent)
+
+	export := ExportPokerStars(h, p1.GetId(), nil)
+	for _, line := range []string{
+		"*** FIRST FLOP *** " + pokerStarsCards(h.Boards[0][:3]),
+		"*** SECOND RIVER *** " + pokerStarsCards(h.Boards[1][:4]) + " " + pokerStarsCards(h.Boards[1][4:]),
+		"Hand was run twice",
+		"SECOND Board " + pokerStarsCards(h.Boards[1]),
+	} {
+		require.True(t, strings.Contains(export, line+"\n"), line)
+	}
+}
+
+func TestRunItOnce(t *testing.T) {
+	table, rec, hands, _, p2, p3 := newAllInTable(t, 0)
+	require.NoError(t, table.RunItVote(p3.GetId(), 1))
+	require.NoError(t, table.RunItVote(p2.GetId(), 2))
+
+	// хватает одного несогласного, чтобы борд открылся один раз
+	require.Equal(t, rec.byType(EventRunItAgreed), []any{RunItAgreed{Runs: 1}})
+	for _, e := range rec.byType(EventCommunityCards) {
+		require.Zero(t, e.(CommunityCardsDealt).Run)
+	}
+	require.Len(t, (*hands)[0].Board, 5)
+	require.Nil(t, (*hands)[0].Boards)
+}
+
+func TestRunItTimeout(t *testing.T) {
+	table, rec, _, _, p2, p3 := newAllInTable(t, time.Hour)
+	require.NoError(t, table.RunItVote(p2.GetId(), 2))
+	table.onRunItExpired(table.Meta.TurnId - 1)
+	require.True(t, table.Meta.GameStarted)
+	table.onRunItExpired(table.Meta.TurnId)
+	require.False(t, table.Meta.GameStarted)
+	require.Equal(t, rec.byType(EventRunItVote), []any{
+		RunItVoted{PlayerId: p2.GetId(), Runs: 2},
+		RunItVoted{PlayerId: p3.GetId(), Runs: 1, Timeout: true},
+	})
+	require.Equal(t, rec.byType(EventRunItAgreed), []any{RunItAgreed{Runs: 1}})
+}
@@ -0,0 +1,96 @@
+package holdem
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+const AnySeat = -1 // игрок садится на первое свободное место
+
+var (
+	ErrBadSeat   = errors.New("seat number out of range")
+	ErrSeatTaken = errors.New("seat already taken")
+)
+
+// takeSeat сажает игрока на место seat от 0 до MaxPlayers-1 или, с AnySeat, на первое свободное
+func (t *PokerTable) takeSeat(playerId string, seat int) error {
+	if len(t.Meta.Seats) >= t.Config.MaxPlayers {
+		return ErrMaxPlayers
+	}
+	taken := make(map[int]bool, len(t.Meta.Seats))
+	for _, s := range t.Meta.Seats {
+		taken[s] = true
+	}
+	if seat == AnySeat {
+		for seat = 0; taken[seat]; seat++ {
+		}
+	}
+	if seat < 0 || seat >= t.Config.MaxPlayers {
+		return ErrBadSeat
+	}
+	if taken[seat] {
+		return ErrSeatTaken
+	}
+	t.Meta.Seats[playerId] = seat
+	return nil
+}
+
+// sortBySeat упорядочивает PlayersOrder по номерам мест
+func (t *PokerTable) sortBySeat() {
+	slices.SortFunc(t.Meta.PlayersOrder, func(a, b string) int { return cmp.Compare(t.Meta.Seats[a], t.Meta.Seats[b]) })
+}
+
+// afterSeat возвращает индекс в PlayersOrder игрока, который на steps участвующих в раздаче игроков
+// дальше по часовой стрелке от места seat. Само место может быть пустым
+func (t *PokerTable) afterSeat(seat, steps int) int {
+	ind := len(t.Meta.PlayersOrder) - 1
+	for i, k := range t.Meta.PlayersOrder {
+		if t.Meta.Seats[k] <= seat {
+			ind = i
+		}
+	}
+	return t.nextSeat(ind, steps)
+}
+
+// seatPlayer возвращает игрока на месте seat, если он участвует в раздаче
+func (t *PokerTable) seatPlayer(seat int) (string, bool) {
+	for _, k := range t.Meta.PlayersOrder {
+		if t.Meta.Seats[k] == seat {
+			return k, !t.sittingOut(k)
+		}
+	}
+	return "", false
+}
+
+// seatBetween показывает, лежит ли место seat на пути по часовой стрелке от from (не включая) до to (включая)
+func seatBetween(seat, from, to int) bool {
+	if from < to {
+		return from < seat && seat <= to
+	}
+	return seat > from || seat <= to
+}
+
+// positions возвращает места баттона, малого и большого блайнда в следующей раздаче по правилу мертвого баттона:
+// большой блайнд всегда переходит к следующему участвующему игроку, малый ставит место прошлого большого блайнда,
+// а баттон встает на место прошлого малого. Если место малого блайнда опустело, малый блайнд не ставится,
+// если опустело место баттона, баттон мертвый. Так никто не пропускает большой блайнд и не ставит его дважды
+func (t *PokerTable) positions() (int, int, int) {
+	seat := func(ind int) int { return t.Meta.Seats[t.Meta.PlayersOrder[ind]] }
+	if t.Meta.BigBlindSeat >= 0 && !t.Config.ButtonBlind {
+		bb := seat(t.afterSeat(t.Meta.BigBlindSeat, 1))
+		if t.activePlayers() == 2 { // в хендз апе баттон ставит малый блайнд
+			sb := seat(t.afterSeat(bb, 1))
+			return sb, sb, bb
+		}
+		if bb != t.Meta.SmallBlindSeat { // иначе баттон и большой блайнд на одном месте: между прошлыми блайндами сел новый игрок
+			return t.Meta.SmallBlindSeat, t.Meta.BigBlindSeat, bb
+		}
+	}
+	// первая раздача и блайнд баттона: баттон переходит к следующему игроку, блайнды ставят следующие за ним
+	button := seat(t.afterSeat(t.Meta.ButtonSeat, 1))
+	if t.activePlayers() == 2 {
+		return button, button, seat(t.afterSeat(button, 1))
+	}
+	return button, seat(t.afterSeat(button, 1)), seat(t.afterSeat(button, 2))
+}
@@ -0,0 +1,104 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPlayerAt(t *testing.T) {
+	config := NewTableConfig(time.Hour, 4, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	table.AddObserver(rec)
+	players := []*Player{}
+	for i := 0; i < 5; i++ {
+		players = append(players, &Player{Id: uuid.New(), Balance: 1000})
+	}
+	require.NoError(t, table.AddPlayerAt(players[0], 3))
+	require.ErrorIs(t, table.AddPlayerAt(players[1], 3), ErrSeatTaken)
+	require.ErrorIs(t, table.AddPlayerAt(players[0], 2), ErrAlreadySeated)
+	require.ErrorIs(t, table.AddPlayerAt(players[1], 4), ErrBadSeat)
+	require.ErrorIs(t, table.AddPlayerAt(players[1], -2), ErrBadSeat)
+	require.NoError(t, table.AddPlayer(players[1]))
+	require.NoError(t, table.AddPlayerAt(players[2], 1))
+	require.Equal(t, rec.byType(EventPlayerEnter), []any{
+		PlayerEntered{PlayerId: players[0].GetId(), Seat: 3},
+		PlayerEntered{PlayerId: players[1].GetId(), Seat: 0},
+		PlayerEntered{PlayerId: players[2].GetId(), Seat: 1},
+	})
+	require.Equal(t, table.GetPlayerList(), []string{players[1].GetId(), players[2].GetId(), players[0].GetId()})
+
+	require.NoError(t, table.AddPlayer(players[3]))
+	require.Equal(t, table.Meta.Seats[players[3].GetId()], 2)
+	require.ErrorIs(t, table.AddPlayer(players[4]), ErrMaxPlayers)
+
+	// место освобождается вместе с уходом игрока
+	require.NoError(t, table.RemovePlayer(players[2].GetId()))
+	require.NoError(t, table.AddPlayerAt(players[4], 1))
+}
+
+func TestDeadButton(t *testing.T) {
+	// в первой раздаче баттон у p2 (место 1), малый блайнд у p3, большой у p4
+	testCases := []struct {
+		name   string
+		leaves int
+		hands  [][3]string // баттон, малый и большой блайнд во второй и третьей раздаче. "" - мертвый баттон или нет малого блайнда
+	}{
+		{"big blind leaves", 3, [][3]string{{"p3", "", "p1"}, {"", "p1", "p2"}}},
+		{"small blind leaves", 2, [][3]string{{"", "p4", "p1"}, {"p4", "p1", "p2"}}},
+		{"button leaves", 1, [][3]string{{"p3", "p4", "p1"}, {"p4", "p1", "p3"}}},
+		{"under the gun leaves", 0, [][3]string{{"p3", "p4", "p2"}, {"p4", "p2", "p3"}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+			table := NewPokerTable(config)
+			rec := &eventRecorder{}
+			hands := &[]HandHistory{}
+			table.AddObserver(rec)
+			table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+			p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+			p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+			p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000}
+			p4 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000004"), Balance: 1000}
+			table.AddPlayer(p1)
+			table.AddPlayer(p2)
+			table.AddPlayer(p3)
+			table.AddPlayer(p4)
+			ps := []*Player{p1, p2, p3, p4}
+			names := map[string]string{"": ""}
+			for i, p := range ps {
+				names["p"+string(rune('1'+i))] = p.GetId()
+			}
+			require.NoError(t, table.StartGame())
+			require.Equal(t, rec.byType(EventDealer), []any{ButtonMoved{PlayerId: ps[1].GetId(), Seat: 1}})
+			foldOut(t, table)
+			require.NoError(t, table.RemovePlayer(ps[tc.leaves].GetId()))
+
+			for i, want := range tc.hands {
+				rec.messages = nil
+				require.NoError(t, table.StartGame())
+				// баттон идет по местам, даже пустым
+				require.Equal(t, rec.byType(EventDealer), []any{ButtonMoved{PlayerId: names[want[0]], Seat: 2 + i}})
+				small := []any{}
+				if want[1] != "" {
+					small = append(small, BlindPosted{PlayerId: names[want[1]], Blind: BlindSmall, Amount: 50})
+				}
+				require.Equal(t, rec.byType(EventSmallBlind), small)
+				require.Equal(t, rec.byType(EventBigBlind), []any{BlindPosted{PlayerId: names[want[2]], Blind: BlindBig, Amount: 100}})
+				foldOut(t, table)
+			}
+
+			for _, h := range *hands {
+				res, err := ReplayHand(h)
+				require.NoError(t, err)
+				require.True(t, res.Consistent)
+			}
+			last := (*hands)[len(*hands)-1]
+			require.Equal(t, last.Button, 4)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package holdem
+
+const (
+	GameShortDeck = "short_deck"
+)
+
+// shortDeckRules - холдем колодой из 36 карт
+type shortDeckRules struct{}
+
+func (shortDeckRules) Name() string                    { return GameShortDeck }
+func (shortDeckRules) Deck() []Card                    { return GetShortDeck() }
+func (shortDeckRules) HoleCards() int                  { return 2 }
+func (shortDeckRules) Streets() []Street               { return holdemStreets }
+func (shortDeckRules) DefaultBettingStructure() string { return BettingNoLimit }
+
+func (shortDeckRules) Evaluate(playerHand []Card, communityCards []Card) Combination {
+	return EvaluateShortDeckHand(playerHand, communityCards)
+}
+
+func (r shortDeckRules) Showdown(communityCards []Card, applicants map[string]IPlayer) []PotShare {
+	return highShowdown(r.Evaluate, communityCards, applicants)
+}
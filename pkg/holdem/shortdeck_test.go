@@ -0,0 +1,90 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateShortDeckHand(t *testing.T) {
+	cases := []struct {
+		TestCaseName   string
+		Evaluate       HandEvaluator
+		PlayerHand     []Card
+		CommunityCards []Card
+		ExpectedRank   int
+	}{
+		{
+			TestCaseName: "Ace plays low in A-6-7-8-9",
+			Evaluate:     EvaluateShortDeckHand,
+			PlayerHand:   []Card{{Suit: "Hearts", Value: 14}, {Suit: "Clubs", Value: 6}},
+			CommunityCards: []Card{
+				{Suit: "Spades", Value: 7}, {Suit: "Hearts", Value: 8},
+				{Suit: "Clubs", Value: 9}, {Suit: "Hearts", Value: 12},
+				{Suit: "Diamonds", Value: 12},
+			},
+			ExpectedRank: Straight,
+		},
+		{
+			TestCaseName: "A-6-7-8-9 is not a straight in standard deck",
+			Evaluate:     EvaluateHand,
+			PlayerHand:   []Card{{Suit: "Hearts", Value: 14}, {Suit: "Clubs", Value: 6}},
+			CommunityCards: []Card{
+				{Suit: "Spades", Value: 7}, {Suit: "Hearts", Value: 8},
+				{Suit: "Clubs", Value: 9}, {Suit: "Hearts", Value: 12},
+				{Suit: "Diamonds", Value: 12},
+			},
+			ExpectedRank: OnePair,
+		},
+	}
+	for _, tCase := range cases {
+		t.Run(tCase.TestCaseName, func(t *testing.T) {
+			combination := tCase.Evaluate(tCase.PlayerHand, tCase.CommunityCards)
+			require.Equal(t, combination.Rank, tCase.ExpectedRank)
+		})
+	}
+}
+
+func TestShortDeckFlushBeatsFullHouse(t *testing.T) {
+	board := []Card{
+		{Suit: "Hearts", Value: 13}, {Suit: "Hearts", Value: 7},
+		{Suit: "Clubs", Value: 7}, {Suit: "Hearts", Value: 10},
+		{Suit: "Spades", Value: 12},
+	}
+	flushHand := []Card{{Suit: "Hearts", Value: 14}, {Suit: "Hearts", Value: 8}}
+	fullHouseHand := []Card{{Suit: "Diamonds", Value: 13}, {Suit: "Spades", Value: 13}}
+
+	require.Greater(t, CompareCombinations(EvaluateShortDeckHand(flushHand, board), EvaluateShortDeckHand(fullHouseHand, board)), 0)
+	require.Less(t, CompareCombinations(EvaluateHand(flushHand, board), EvaluateHand(fullHouseHand, board)), 0)
+}
+
+func TestShortDeckTable(t *testing.T) {
+	require.Len(t, GetShortDeck(), 36)
+
+	config := NewTableConfig(time.Hour, 10, 2, 50, 10, 0, false, 1488)
+	require.NoError(t, config.SetGameType(GameShortDeck))
+	config.ButtonBlind = true
+	table := NewPokerTable(config)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000}
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+
+	for _, c := range table.Meta.Deck {
+		require.GreaterOrEqual(t, c.Value, 6)
+	}
+	// дилер p2 ставит блайнд баттона, остальные только анте. Первым ходит p3
+	require.Equal(t, p2.Balance, 1000-10-100)
+	require.Equal(t, p3.Balance, 1000-10)
+	require.Equal(t, table.Meta.CurrentBet, 100)
+	require.ErrorIs(t, table.MakeMove(p1.GetId(), "call", 0), ErrNotYourTurn)
+	require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+	require.NoError(t, table.MakeMove(p1.GetId(), "call", 0))
+	require.NoError(t, table.MakeMove(p2.GetId(), "check", 0))
+	require.Equal(t, table.Meta.CurrentRound, 1)
+}
@@ -0,0 +1,128 @@
+package holdem
+
+import (
+	"errors"
+	"slices"
+)
+
+const ActionShow = "show" // игрок откроет карты в конце раздачи
+
+var ErrAlreadyShown = errors.New("cards already shown")
+
+// ShowCards - игрок откроет карты в конце раздачи, даже если заберет банк без вскрытия или проиграет на вскрытии.
+// После раздачи и до начала следующей не сбросившие карты открывают их сразу
+func (t *PokerTable) ShowCards(playerId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.checkShowCards(playerId); err != nil {
+		t.notify([]string{playerId}, EventBadMove, newBadMove(playerId, ActionShow, 0, err))
+		return err
+	}
+	t.notify(nil, EventShowRequest, ShowRequested{PlayerId: playerId})
+	if t.Meta.GameStarted {
+		t.Meta.ShowRequests[playerId] = true
+		return nil
+	}
+	t.showCards(playerId)
+	return nil
+}
+
+func (t *PokerTable) checkShowCards(playerId string) error {
+	p, ok := t.Meta.Players[playerId]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if t.Meta.GameStarted {
+		if p.GetFold() {
+			return ErrPlayerIsFold
+		}
+		return nil
+	}
+	if !slices.Contains(t.Meta.Dealt, playerId) {
+		return ErrGameNotStarted
+	}
+	if !slices.Contains(t.Meta.Remaining, playerId) {
+		return ErrPlayerIsFold
+	}
+	if t.Meta.Shown[playerId] {
+		return ErrAlreadyShown
+	}
+	return nil
+}
+
+// showdownBoards возвращает борды, по которым делятся банки
+func (t *PokerTable) showdownBoards() [][]Card {
+	if len(t.Meta.Boards) != 0 {
+		return t.Meta.Boards
+	}
+	return [][]Card{t.Meta.CommunityCards}
+}
+
+// potApplicants возвращает игроков, которые вложились в банк и не сбросили карты
+func (t *PokerTable) potApplicants(pot Pot) map[string]IPlayer {
+	applicants := make(map[string]IPlayer)
+	for _, k := range pot.Applicants {
+		if p := t.Meta.Players[k]; !p.GetFold() {
+			applicants[k] = p
+		}
+	}
+	return applicants
+}
+
+// potWinners возвращает всех, кто выигрывает хоть какую то часть банков
+func (t *PokerTable) potWinners() map[string]bool {
+	winners := make(map[string]bool)
+	for _, pot := range t.Meta.Pots {
+		applicants := t.potApplicants(pot)
+		for _, board := range t.showdownBoards() {
+			for _, share := range t.Config.Rules().Showdown(board, applicants) {
+				for _, k := range share.Winners {
+					winners[k] = true
+				}
+			}
+		}
+	}
+	return winners
+}
+
+// showdown открывает карты перед выплатами. Первым показывает последний, кто ставил или повышал на последней улице,
+// а если ставок не было - первый слева от баттона. Дальше по часовой стрелке карты показывают только те,
+// кто забирает хотя бы часть банка, остальные сбрасывают их в пас. Если торговля закончилась all in, открываются все.
+// Без вскрытия карты показывает только попросивший об этом
+func (t *PokerTable) showdown() {
+	first := t.afterSeat(t.Meta.ButtonSeat, 1)
+	if k := t.Meta.LastAggressor; k != "" && !t.Meta.Players[k].GetFold() {
+		first = slices.Index(t.Meta.PlayersOrder, k)
+	}
+	inHand := []string{}
+	withChips := 0
+	for i := range t.Meta.PlayersOrder {
+		k := t.Meta.PlayersOrder[(first+i)%len(t.Meta.PlayersOrder)]
+		if t.Meta.Players[k].GetFold() {
+			continue
+		}
+		inHand = append(inHand, k)
+		if t.Meta.Players[k].GetBalance() != 0 {
+			withChips++
+		}
+	}
+	if len(inHand) == 1 {
+		if t.Meta.ShowRequests[inHand[0]] {
+			t.showCards(inHand[0])
+		}
+		return
+	}
+	winners := t.potWinners()
+	for i, k := range inHand {
+		if i == 0 || withChips <= 1 || winners[k] || t.Meta.ShowRequests[k] {
+			t.showCards(k)
+			continue
+		}
+		t.notify(t.public(), EventMuck, PlayerMucked{PlayerId: k})
+	}
+}
+
+func (t *PokerTable) showCards(playerId string) {
+	t.Meta.Shown[playerId] = true
+	t.notify(t.public(), EventShow, CardsShown{PlayerId: playerId, Cards: t.Meta.Players[playerId].GetHand().Cards})
+}
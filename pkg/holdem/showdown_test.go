@@ -0,0 +1,213 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowdownOrder(t *testing.T) {
+	testCases := []struct {
+		name      string
+		riverBet  bool
+		firstShow int // индекс в порядке мест p1, p2, p3
+	}{
+		{"last aggressor shows first", true, 0},
+		{"left of the button without bets", false, 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+			table := NewPokerTable(config)
+			rec := &eventRecorder{}
+			hands := &[]HandHistory{}
+			table.AddObserver(rec)
+			table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+			p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+			p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+			p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+			table.AddPlayer(p1)
+			table.AddPlayer(p2)
+			table.AddPlayer(p3)
+			table.StartGame()
+			players := []*Player{p1, p2, p3}
+			require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+			require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+			require.NoError(t, table.MakeMove(p1.GetId(), "check", 0))
+			// ставка p2 на флопе не делает его первым на вскрытии
+			require.NoError(t, table.MakeMove(p3.GetId(), "check", 0))
+			require.NoError(t, table.MakeMove(p1.GetId(), "check", 0))
+			require.NoError(t, table.MakeMove(p2.GetId(), "bet", 100))
+			require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+			require.NoError(t, table.MakeMove(p1.GetId(), "call", 0))
+			for i := 0; i < 3; i++ {
+				require.NoError(t, table.MakeMove(table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], "check", 0))
+			}
+			if tc.riverBet {
+				require.NoError(t, table.MakeMove(p3.GetId(), "check", 0))
+				require.NoError(t, table.MakeMove(p1.GetId(), "bet", 100))
+				require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+				require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+			} else {
+				for i := 0; i < 3; i++ {
+					require.NoError(t, table.MakeMove(table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], "check", 0))
+				}
+			}
+			require.False(t, table.Meta.GameStarted)
+
+			winners := map[string]bool{}
+			for _, e := range rec.byType(EventWinPot) {
+				for _, w := range e.(PotAwarded).Winners {
+					winners[w] = true
+				}
+			}
+			order := []string{}
+			shown := map[string]bool{}
+			for _, m := range rec.messages {
+				switch e := m.EventData.(type) {
+				case CardsShown:
+					order = append(order, e.PlayerId)
+					shown[e.PlayerId] = true
+					require.Equal(t, e.Cards, table.Meta.Players[e.PlayerId].GetHand().Cards)
+				case PlayerMucked:
+					order = append(order, e.PlayerId)
+				}
+			}
+			// по часовой стрелке от первого, проигравшие после первого сбрасывают карты
+			expected := []string{}
+			for i := range players {
+				expected = append(expected, players[(tc.firstShow+i)%3].GetId())
+			}
+			require.Equal(t, order, expected)
+			for i, k := range order {
+				require.Equal(t, shown[k], i == 0 || winners[k])
+			}
+
+			stats := rec.byType(EventPlayersStats)
+			for _, p := range stats[len(stats)-1].(PlayersStats).Players {
+				require.Equal(t, p.Hand != nil, shown[p.Id])
+			}
+
+			require.Len(t, *hands, 1)
+			h := (*hands)[0]
+			require.Equal(t, h.ShowOrder, expected)
+			for _, s := range h.Seats {
+				require.Equal(t, s.Shown, shown[s.PlayerId])
+				require.Equal(t, s.Mucked, !shown[s.PlayerId])
+				if s.PlayerId != p2.GetId() {
+					require.Equal(t, len(mustSeat(t, h.ForPlayer(p2.GetId()), s.PlayerId).Cards) != 0, shown[s.PlayerId])
+				}
+			}
+		})
+	}
+}
+
+func TestShowWithoutShowdown(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	hands := &[]HandHistory{}
+	table.AddObserver(rec)
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+	require.NoError(t, table.MakeMove(p2.GetId(), "raise", 300))
+	require.NoError(t, table.MakeMove(p3.GetId(), "fold", 0))
+	require.ErrorIs(t, table.ShowCards(p3.GetId()), ErrPlayerIsFold)
+	require.Equal(t, rec.byType(EventBadMove), []any{BadMove{PlayerId: p3.GetId(), Action: ActionShow, Error: ErrPlayerIsFold.Error()}})
+	stranger := uuid.NewString()
+	require.ErrorIs(t, table.ShowCards(stranger), ErrPlayerNotFound)
+	require.NoError(t, table.ShowCards(p2.GetId()))
+	require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+
+	require.Equal(t, rec.byType(EventShow), []any{CardsShown{PlayerId: p2.GetId(), Cards: p2.Hand.Cards}})
+	require.Empty(t, rec.byType(EventMuck))
+	stats := rec.byType(EventPlayersStats)
+	for _, p := range stats[len(stats)-1].(PlayersStats).Players {
+		require.Equal(t, p.Hand != nil, p.Id == p2.GetId())
+	}
+
+	// при повторе карты открываются так же
+	h := (*hands)[0]
+	require.Equal(t, h.Replay.Moves[2:5], []LoggedMove{
+		{PlayerId: p3.GetId(), Action: ActionShow, Offset: h.Replay.Moves[2].Offset},
+		{PlayerId: stranger, Action: ActionShow, Offset: h.Replay.Moves[3].Offset},
+		{PlayerId: p2.GetId(), Action: ActionShow, Offset: h.Replay.Moves[4].Offset},
+	})
+	res, err := ReplayHand(h)
+	require.NoError(t, err)
+	require.True(t, res.Consistent)
+	require.True(t, mustSeat(t, res.Hand, p2.GetId()).Shown)
+	requireSameMessages(t, res, rec.messages)
+
+	// в следующей раздаче просьба уже не действует
+	cards := p2.Hand.Cards
+	require.NoError(t, table.StartGame())
+	require.Empty(t, table.Meta.ShowRequests)
+
+	require.False(t, h.Showdown)
+	require.True(t, mustSeat(t, h, p2.GetId()).Shown)
+	require.Len(t, mustSeat(t, h.ForPlayer(p1.GetId()), p2.GetId()).Cards, 2)
+	require.Len(t, mustSeat(t, h.ForPlayer(p1.GetId()), p3.GetId()).Cards, 0)
+	export := ExportPokerStars(h.ForPlayer(p1.GetId()), p1.GetId(), nil)
+	require.Contains(t, export, p2.GetId()+" collected 250 from pot\n"+p2.GetId()+": shows "+pokerStarsCards(cards)+"\n")
+	require.NotContains(t, export, "*** SHOW DOWN ***")
+}
+
+func TestShowAfterHand(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	hands := &[]HandHistory{}
+	table.AddObserver(rec)
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+	require.NoError(t, table.MakeMove(p2.GetId(), "raise", 300))
+	require.NoError(t, table.MakeMove(p3.GetId(), "fold", 0))
+	require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+	require.Empty(t, rec.byType(EventShow))
+	require.Len(t, *hands, 1)
+	require.False(t, mustSeat(t, (*hands)[0], p2.GetId()).Shown)
+
+	// до следующей раздачи открыть карты может только тот, кто их не сбросил
+	require.NoError(t, table.ShowCards(p2.GetId()))
+	shown := len(rec.messages)
+	require.ErrorIs(t, table.ShowCards(p3.GetId()), ErrPlayerIsFold)
+	require.ErrorIs(t, table.ShowCards(p2.GetId()), ErrAlreadyShown)
+	require.Equal(t, rec.byType(EventShow), []any{CardsShown{PlayerId: p2.GetId(), Cards: p2.Hand.Cards}})
+	require.Len(t, rec.byType(EventBadMove), 2)
+
+	// раздача сохраняется еще раз с открытыми картами
+	require.Len(t, *hands, 2)
+	require.False(t, mustSeat(t, (*hands)[0], p2.GetId()).Shown)
+	h := (*hands)[1]
+	require.Equal(t, h.Hand, (*hands)[0].Hand)
+	require.True(t, mustSeat(t, h, p2.GetId()).Shown)
+	require.Equal(t, h.ShowOrder, []string{p2.GetId()})
+	require.Len(t, mustSeat(t, h.ForPlayer(p1.GetId()), p2.GetId()).Cards, 2)
+	require.Equal(t, table.GetState(p1.GetId()).Players[1].Hand, &p2.Hand)
+	res, err := ReplayHand(h)
+	require.NoError(t, err)
+	require.True(t, res.Consistent)
+	require.Equal(t, res.Hand.ShowOrder, []string{p2.GetId()})
+	requireSameMessages(t, res, rec.messages[:shown])
+
+	// со следующей раздачей просьба снова откладывается до ее конца
+	require.NoError(t, table.StartGame())
+	require.NoError(t, table.ShowCards(p2.GetId()))
+	require.Len(t, rec.byType(EventShow), 1)
+	require.Len(t, *hands, 2)
+}
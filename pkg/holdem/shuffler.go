@@ -0,0 +1,104 @@
+package holdem
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// IShuffler перемешивает колоду перед каждой раздачей. Стол вызывает его под своим мьютексом
+type IShuffler interface {
+	// Commit возвращает хеш, который публикуется до раздачи. Пустая строка - перемешивание нельзя проверить
+	Commit() string
+	// Shuffle перемешивает колоду раздачи с учетом сидов игроков. Для проверяемого перемешивания
+	// возвращает то, что раскрывается после раздачи, иначе nil
+	Shuffle(deck []Card, clientSeeds []ClientSeed) *ShuffleProof
+}
+
+// CryptoShuffler - перемешивание commit-reveal для игры на сервере. Сид сервера берется из crypto/rand,
+// его хеш публикуется до раздачи, а сам сид раскрывается после нее
+type CryptoShuffler struct {
+	next string
+}
+
+func NewCryptoShuffler() *CryptoShuffler {
+	return &CryptoShuffler{}
+}
+
+func (s *CryptoShuffler) Commit() string {
+	if s.next == "" {
+		s.next = NewServerSeed()
+	}
+	return SeedCommitment(s.next)
+}
+
+func (s *CryptoShuffler) Shuffle(deck []Card, clientSeeds []ClientSeed) *ShuffleProof {
+	commitment := s.Commit()
+	seed := s.next
+	s.next = ""
+	fairShuffle(deck, shuffleKey(seed, clientSeeds))
+	return &ShuffleProof{ServerSeed: seed, Commitment: commitment, ClientSeeds: clientSeeds}
+}
+
+// SeededShuffler перемешивает math/rand с заданным сидом, для тестов. Сид каждой следующей раздачи
+// выводится из предыдущего, поэтому вся последовательность раздач повторяется
+type SeededShuffler struct {
+	seed int64
+}
+
+func NewSeededShuffler(seed int64) *SeededShuffler {
+	return &SeededShuffler{seed: seed}
+}
+
+func (s *SeededShuffler) Commit() string {
+	return ""
+}
+
+func (s *SeededShuffler) Shuffle(deck []Card, clientSeeds []ClientSeed) *ShuffleProof {
+	r := rand.New(rand.NewSource(s.seed))
+	r.Shuffle(len(deck), func(i, j int) {
+		deck[i], deck[j] = deck[j], deck[i]
+	})
+	next := rand.New(rand.NewSource(s.seed))
+	for {
+		if newSeed := next.Int63(); newSeed != 0 {
+			s.seed = newSeed
+			break
+		}
+	}
+	return nil
+}
+
+// ScriptedShuffler раскладывает колоду по сценарию: в каждой раздаче сверху лежат заданные карты в заданном порядке,
+// остальные - за ними в исходном порядке колоды. Когда сценарий закончился, колода не перемешивается
+type ScriptedShuffler struct {
+	decks [][]Card
+}
+
+func NewScriptedShuffler(decks ...[]Card) *ScriptedShuffler {
+	return &ScriptedShuffler{decks: decks}
+}
+
+func (s *ScriptedShuffler) Commit() string {
+	return ""
+}
+
+func (s *ScriptedShuffler) Shuffle(deck []Card, clientSeeds []ClientSeed) *ShuffleProof {
+	if len(s.decks) == 0 {
+		return nil
+	}
+	top := s.decks[0]
+	s.decks = s.decks[1:]
+	ordered := make([]Card, 0, len(deck))
+	for _, c := range top {
+		if slices.Contains(deck, c) && !slices.Contains(ordered, c) {
+			ordered = append(ordered, c)
+		}
+	}
+	for _, c := range deck {
+		if !slices.Contains(ordered, c) {
+			ordered = append(ordered, c)
+		}
+	}
+	copy(deck, ordered)
+	return nil
+}
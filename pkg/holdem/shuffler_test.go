@@ -0,0 +1,72 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCryptoShuffler(t *testing.T) {
+	s := NewCryptoShuffler()
+	commitment := s.Commit()
+	require.Equal(t, s.Commit(), commitment)
+
+	deck := GetStandardDeck()
+	seeds := []ClientSeed{{PlayerId: "p1", Seed: "abc"}}
+	proof := s.Shuffle(deck, seeds)
+	require.NotNil(t, proof)
+	require.Equal(t, proof.Commitment, commitment)
+	require.NotEqual(t, s.Commit(), commitment)
+
+	proof.GameType = GameHoldem
+	verified, err := VerifyShuffle(*proof)
+	require.NoError(t, err)
+	require.Equal(t, verified, deck)
+	require.ElementsMatch(t, deck, GetStandardDeck())
+}
+
+func TestSeededShuffler(t *testing.T) {
+	a, b := NewSeededShuffler(1488), NewSeededShuffler(1488)
+	first, second := GetStandardDeck(), GetStandardDeck()
+	require.Nil(t, a.Shuffle(first, nil))
+	b.Shuffle(second, nil)
+	require.Equal(t, first, second)
+	require.Empty(t, a.Commit())
+
+	// следующая раздача перемешивается по-другому, но так же повторяется
+	next, other := GetStandardDeck(), GetStandardDeck()
+	a.Shuffle(next, nil)
+	b.Shuffle(other, nil)
+	require.Equal(t, next, other)
+	require.NotEqual(t, next, first)
+}
+
+func TestScriptedShuffler(t *testing.T) {
+	aces := []Card{{"Spades", 14}, {"Hearts", 14}}
+	kings := []Card{{"Spades", 13}, {"Hearts", 13}}
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 0)
+	config.Shuffler = NewScriptedShuffler(append(aces, kings...))
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	table.AddObserver(rec)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.StartGame()
+
+	require.Equal(t, rec.byType(EventGetCards), []any{
+		CardsDealt{PlayerId: p1.GetId(), Cards: aces},
+		CardsDealt{PlayerId: p2.GetId(), Cards: kings},
+	})
+	require.Empty(t, rec.byType(EventShuffleCommit))
+	require.Len(t, table.Meta.HandDeck, 52)
+	require.ElementsMatch(t, table.Meta.HandDeck, GetStandardDeck())
+
+	// сценарий закончился - колода не перемешивается
+	deck := GetStandardDeck()
+	require.Nil(t, config.Shuffler.Shuffle(deck, nil))
+	require.Equal(t, deck, GetStandardDeck())
+}
@@ -0,0 +1,197 @@
+package holdem
+
+import (
+	"errors"
+)
+
+const (
+	ActionSitOut = "sit_out"
+	ActionSitIn  = "sit_in" // amount != 0 - сразу поставить пропущенный блайнд
+
+	SitOutRequest  = "request"  // игрок сам попросил
+	SitOutTimeouts = "timeouts" // пропустил подряд sit_out_timeouts ходов
+	SitOutBlind    = "blind"    // пропустил последний ход, а теперь его очередь ставить блайнд
+	SitOutLeaving  = "leaving"  // уходит из-за стола, место освободится после раздачи
+)
+
+var (
+	ErrAlreadySittingOut = errors.New("player already sitting out")
+	ErrNotSittingOut     = errors.New("player is not sitting out")
+	ErrNotEnoughPlayers  = errors.New("not enough players to start the game")
+)
+
+const (
+	sitOutStay       = iota // сидит, пока не попросит вернуться
+	sitOutNextHand          // вернется со следующей раздачи
+	sitOutAtBigBlind        // вернется, когда до него дойдет большой блайнд
+)
+
+// SitOut - игрок сидит за столом со своим стеком, но ему не раздают карты, он не ставит блайнды и не ходит
+type SitOut struct {
+	Return      int
+	MissedBlind bool // пока он сидел, блайнды прошли его место
+	PostMissed  bool // при возвращении ставит пропущенный блайнд в банк
+}
+
+// sittingOut показывает, что игрок пропускает раздачи
+func (t *PokerTable) sittingOut(playerId string) bool {
+	_, ok := t.Meta.SittingOut[playerId]
+	return ok
+}
+
+// activePlayers - сколько игроков участвуют в раздачах
+func (t *PokerTable) activePlayers() int {
+	return len(t.Meta.PlayersOrder) - len(t.Meta.SittingOut)
+}
+
+// nextSeat возвращает индекс в PlayersOrder игрока, который на steps мест дальше ind, пропуская сидящих в стороне
+func (t *PokerTable) nextSeat(ind, steps int) int {
+	n := len(t.Meta.PlayersOrder)
+	for steps > 0 {
+		ind = (ind + 1) % n
+		if !t.sittingOut(t.Meta.PlayersOrder[ind]) {
+			steps--
+		}
+	}
+	return ind
+}
+
+// SitOut - игрок пропускает раздачи со следующей, оставаясь за столом
+func (t *PokerTable) SitOut(playerId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.checkSitOut(playerId); err != nil {
+		t.notify([]string{playerId}, EventBadMove, newBadMove(playerId, ActionSitOut, 0, err))
+		return err
+	}
+	if s, ok := t.Meta.SittingOut[playerId]; ok {
+		s.Return = sitOutStay
+		s.PostMissed = false
+	}
+	t.sitOut(playerId, SitOutRequest)
+	return nil
+}
+
+func (t *PokerTable) checkSitOut(playerId string) error {
+	if _, ok := t.Meta.Players[playerId]; !ok {
+		return ErrPlayerNotFound
+	}
+	if s, ok := t.Meta.SittingOut[playerId]; ok && s.Return == sitOutStay {
+		return ErrAlreadySittingOut
+	}
+	return nil
+}
+
+func (t *PokerTable) sitOut(playerId, reason string) {
+	if _, ok := t.Meta.SittingOut[playerId]; !ok {
+		t.Meta.SittingOut[playerId] = &SitOut{}
+	}
+	delete(t.Meta.Timeouts, playerId)
+	t.notify(t.public(), EventSitOut, PlayerSatOut{PlayerId: playerId, Reason: reason})
+}
+
+// SitIn возвращает игрока в игру со следующей раздачи. Если он пропустил блайнды, то либо сразу ставит
+// большой блайнд мертвыми фишками в банк (postMissed), либо ждет, когда до него дойдет большой блайнд
+func (t *PokerTable) SitIn(playerId string, postMissed bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.Meta.SittingOut[playerId]
+	if !ok {
+		t.notify([]string{playerId}, EventBadMove, newBadMove(playerId, ActionSitIn, 0, ErrNotSittingOut))
+		return ErrNotSittingOut
+	}
+	s.Return = sitOutNextHand
+	s.PostMissed = s.MissedBlind && postMissed
+	if s.MissedBlind && !postMissed {
+		s.Return = sitOutAtBigBlind
+	}
+	t.notify(t.public(), EventSitIn, PlayerSatIn{PlayerId: playerId, WaitBigBlind: s.Return == sitOutAtBigBlind})
+	return nil
+}
+
+// countTimeout считает ходы по таймеру подряд. После sit_out_timeouts таких ходов игрок пересаживается в сторону
+func (t *PokerTable) countTimeout(playerId string) {
+	if t.Config.SitOutTimeouts <= 0 || t.sittingOut(playerId) {
+		return
+	}
+	if _, ok := t.Meta.Players[playerId]; !ok {
+		return
+	}
+	t.Meta.Timeouts[playerId]++
+	if t.Meta.Timeouts[playerId] >= t.Config.SitOutTimeouts {
+		t.sitOut(playerId, SitOutTimeouts)
+	}
+}
+
+// canStart показывает, хватит ли игроков на раздачу с учетом тех, кто возвращается
+func (t *PokerTable) canStart() bool {
+	players := len(t.Meta.Players) + len(t.Meta.Query)
+	for _, s := range t.Meta.SittingOut {
+		if s.Return == sitOutStay {
+			players--
+		}
+	}
+	return players >= 2
+}
+
+// seatPlayers перед раздачей решает, кто в ней участвует: возвращает вернувшихся, пересаживает в сторону
+// отошедших игроков, до которых дошел блайнд, и отмечает пропустивших блайнды. Сидящие в стороне сбрасывают карты
+func (t *PokerTable) seatPlayers() {
+	t.Meta.DeadBlinds = []string{}
+	for _, k := range t.Meta.PlayersOrder {
+		if s, ok := t.Meta.SittingOut[k]; ok && s.Return == sitOutNextHand {
+			delete(t.Meta.SittingOut, k)
+			if s.PostMissed {
+				t.Meta.DeadBlinds = append(t.Meta.DeadBlinds, k)
+			}
+		}
+	}
+	for _, k := range t.Meta.PlayersOrder {
+		s, ok := t.Meta.SittingOut[k]
+		if !ok || s.Return != sitOutAtBigBlind {
+			continue
+		}
+		delete(t.Meta.SittingOut, k)
+		if _, _, bb := t.positions(); t.Meta.Seats[k] != bb && t.activePlayers() > 2 {
+			t.Meta.SittingOut[k] = s
+		}
+	}
+	for t.Config.SitOutTimeouts > 0 && t.activePlayers() > 2 {
+		_, sb, bb := t.positions()
+		away := ""
+		for _, seat := range []int{sb, bb} {
+			if k, ok := t.seatPlayer(seat); ok && t.Meta.Timeouts[k] > 0 {
+				away = k
+				break
+			}
+		}
+		if away == "" {
+			break
+		}
+		t.sitOut(away, SitOutBlind)
+	}
+	// блайнды проходят места от баттона до большого блайнда включительно
+	if t.activePlayers() > 1 {
+		button, _, bb := t.positions()
+		for k, s := range t.Meta.SittingOut {
+			if seatBetween(t.Meta.Seats[k], button, bb) {
+				s.MissedBlind = true
+			}
+		}
+	}
+	for k := range t.Meta.SittingOut {
+		t.Meta.Players[k].SetFold(true)
+		t.Meta.Players[k].SetHand(Hand{})
+	}
+}
+
+// betDeadBlinds - вернувшиеся игроки ставят пропущенный большой блайнд. Фишки идут в банк, но не в ставку улицы
+func (t *PokerTable) betDeadBlinds() {
+	for _, k := range t.Meta.DeadBlinds {
+		p := t.Meta.Players[k]
+		bet := min(t.Config.SmallBlind*2, p.GetBalance())
+		p.ChangeBalance(-bet)
+		p.SetTotalBet(p.GetTotalBet() + bet)
+		t.notify(t.public(), EventMissedBlind, BlindPosted{PlayerId: k, Blind: BlindMissed, Amount: bet})
+	}
+}
@@ -0,0 +1,188 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// foldOut - все по очереди сбрасывают, пока раздача не закончится
+func foldOut(t *testing.T, table *PokerTable) {
+	for table.Meta.GameStarted {
+		require.NoError(t, table.MakeMove(table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], "fold", 0))
+	}
+}
+
+func TestSitOut(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	hands := &[]HandHistory{}
+	table.AddObserver(rec)
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000}
+	p4 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000004"), Balance: 1000}
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.AddPlayer(p4)
+	require.ErrorIs(t, table.SitIn(p3.GetId(), false), ErrNotSittingOut)
+	require.NoError(t, table.SitOut(p3.GetId()))
+	require.ErrorIs(t, table.SitOut(p3.GetId()), ErrAlreadySittingOut)
+	stranger := uuid.NewString()
+	require.ErrorIs(t, table.SitOut(stranger), ErrPlayerNotFound)
+	require.Equal(t, rec.byType(EventBadMove), []any{
+		BadMove{PlayerId: p3.GetId(), Action: ActionSitIn, Error: ErrNotSittingOut.Error()},
+		BadMove{PlayerId: p3.GetId(), Action: ActionSitOut, Error: ErrAlreadySittingOut.Error()},
+		BadMove{PlayerId: stranger, Action: ActionSitOut, Error: ErrPlayerNotFound.Error()},
+	})
+
+	// p3 пропускает раздачу: блайнды и карты достаются остальным
+	require.NoError(t, table.StartGame())
+	require.Equal(t, rec.byType(EventSitOut), []any{PlayerSatOut{PlayerId: p3.GetId(), Reason: SitOutRequest}})
+	require.Equal(t, rec.byType(EventSmallBlind)[0].(BlindPosted).PlayerId, p4.GetId())
+	require.Equal(t, rec.byType(EventBigBlind)[0].(BlindPosted).PlayerId, p1.GetId())
+	for _, e := range rec.byType(EventGetCards) {
+		require.NotEqual(t, e.(CardsDealt).PlayerId, p3.GetId())
+	}
+	require.Equal(t, table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], p2.GetId())
+	foldOut(t, table)
+	require.Equal(t, p3.GetBalance(), 1000)
+	require.True(t, table.Meta.SittingOut[p3.GetId()].MissedBlind)
+
+	// вернулся, сразу поставив пропущенный блайнд
+	require.NoError(t, table.SitIn(p3.GetId(), true))
+	require.Equal(t, rec.byType(EventSitIn), []any{PlayerSatIn{PlayerId: p3.GetId()}})
+	require.NoError(t, table.StartGame())
+	require.Empty(t, table.Meta.SittingOut)
+	require.Equal(t, rec.byType(EventMissedBlind), []any{BlindPosted{PlayerId: p3.GetId(), Blind: BlindMissed, Amount: 100}})
+	require.Len(t, rec.byType(EventGetCards), 3+4)
+	foldOut(t, table)
+
+	require.Len(t, *hands, 2)
+	first, second := (*hands)[0], (*hands)[1]
+	require.True(t, first.Seats[2].SittingOut)
+	require.Contains(t, ExportPokerStars(first, p1.GetId(), nil), "Seat 3: 00000000-0000-0000-0000-000000000003 (1000 in chips) is sitting out\n")
+	require.Equal(t, second.Replay.DeadBlinds, []string{p3.GetId()})
+	require.Equal(t, second.Pot, 200)
+	for _, h := range *hands {
+		res, err := ReplayHand(h)
+		require.NoError(t, err)
+		require.True(t, res.Consistent)
+	}
+}
+
+func TestSitInAtBigBlind(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	table.AddObserver(rec)
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000}
+	table.AddPlayer(&Player{Id: uuid.New(), Balance: 1000})
+	table.AddPlayer(&Player{Id: uuid.New(), Balance: 1000})
+	table.AddPlayer(p3)
+	table.AddPlayer(&Player{Id: uuid.New(), Balance: 1000})
+	require.NoError(t, table.SitOut(p3.GetId()))
+	require.NoError(t, table.StartGame())
+	foldOut(t, table)
+
+	require.NoError(t, table.SitIn(p3.GetId(), false))
+	require.Equal(t, rec.byType(EventSitIn), []any{PlayerSatIn{PlayerId: p3.GetId(), WaitBigBlind: true}})
+	// большой блайнд еще не дошел до p3
+	require.NoError(t, table.StartGame())
+	require.True(t, table.sittingOut(p3.GetId()))
+	foldOut(t, table)
+
+	require.NoError(t, table.StartGame())
+	require.False(t, table.sittingOut(p3.GetId()))
+	require.Equal(t, rec.byType(EventBigBlind)[2], BlindPosted{PlayerId: p3.GetId(), Blind: BlindBig, Amount: 100})
+	require.Empty(t, rec.byType(EventMissedBlind))
+}
+
+func TestAutoSitOut(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	table.AddObserver(rec)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000}
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000}
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.AddPlayer(&Player{Id: uuid.New(), Balance: 1000})
+	table.Config.SitOutTimeouts = 2
+	require.NoError(t, table.StartGame())
+	table.mu.Lock()
+	table.timeoutMove(p1.GetId())
+	table.mu.Unlock()
+	require.Equal(t, table.Meta.Timeouts[p1.GetId()], 1)
+	require.Empty(t, rec.byType(EventSitOut))
+	foldOut(t, table)
+
+	// p1 отошел, а теперь его большой блайнд
+	require.NoError(t, table.StartGame())
+	require.Equal(t, rec.byType(EventSitOut), []any{PlayerSatOut{PlayerId: p1.GetId(), Reason: SitOutBlind}})
+	require.Equal(t, rec.byType(EventBigBlind)[1].(BlindPosted).PlayerId, p2.GetId())
+
+	// ход по таймеру засчитывается подряд, пока игрок сам не походит
+	table.Config.SitOutTimeouts = 1
+	first := table.Meta.PlayersOrder[table.Meta.PlayerTurnInd]
+	require.Equal(t, first, p3.GetId())
+	table.mu.Lock()
+	table.timeoutMove(first)
+	table.mu.Unlock()
+	require.Equal(t, rec.byType(EventSitOut)[1], PlayerSatOut{PlayerId: p3.GetId(), Reason: SitOutTimeouts})
+	foldOut(t, table)
+	require.Empty(t, table.Meta.Timeouts)
+
+	// остался один игрок, который не отошел
+	require.NoError(t, table.SitOut(p2.GetId()))
+	require.ErrorIs(t, table.StartGame(), ErrNotEnoughPlayers)
+}
+
+func TestLeaveDuringHand(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	table.AddObserver(rec)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+
+	// p2 уходит на своем ходу и сразу сбрасывает
+	require.NoError(t, table.Leave(p2.GetId()))
+	require.True(t, p2.GetFold())
+	require.True(t, table.CheckPlayer(p2.GetId()))
+
+	// p1 уходит не на своем ходу, fold делается, когда ход до него доходит
+	require.NoError(t, table.Leave(p1.GetId()))
+	require.False(t, p1.GetFold())
+	require.Equal(t, rec.byType(EventSitOut), []any{
+		PlayerSatOut{PlayerId: p2.GetId(), Reason: SitOutLeaving},
+		PlayerSatOut{PlayerId: p1.GetId(), Reason: SitOutLeaving},
+	})
+	require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+	require.Equal(t, rec.byType(EventDo), []any{
+		PlayerAction{PlayerId: p2.GetId(), Action: "fold"},
+		PlayerAction{PlayerId: p3.GetId(), Action: "call", Amount: 100},
+		PlayerAction{PlayerId: p1.GetId(), Action: "fold", Amount: 100},
+	})
+
+	// место освобождается после раздачи
+	require.False(t, table.Meta.GameStarted)
+	require.False(t, table.CheckPlayer(p1.GetId()))
+	require.False(t, table.CheckPlayer(p2.GetId()))
+	require.Equal(t, table.Meta.PlayersOrder, []string{p3.GetId()})
+	require.Equal(t, p1.Balance, 900)
+	require.Equal(t, p2.Balance, 1000)
+	require.Equal(t, p3.Balance, 1100)
+}
@@ -0,0 +1,55 @@
+package holdem
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	ErrMaxSpectators     = errors.New("count of spectators reached max value")
+	ErrAlreadySeated     = errors.New("player already seated at this table")
+	ErrSpectatorNotFound = errors.New("spectator not found")
+)
+
+// AddSpectator - зритель получает все открытые события стола, но не видит карт игроков, пока их не откроют.
+// Повторный вход того же зрителя ничего не меняет
+func (t *PokerTable) AddSpectator(spectatorId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.CheckPlayer(spectatorId) {
+		return ErrAlreadySeated
+	}
+	if slices.Contains(t.Meta.Spectators, spectatorId) {
+		return nil
+	}
+	if t.Config.MaxSpectators > 0 && len(t.Meta.Spectators) >= t.Config.MaxSpectators {
+		return ErrMaxSpectators
+	}
+	t.Meta.Spectators = append(t.Meta.Spectators, spectatorId)
+	t.Config.CurrentSpectators = len(t.Meta.Spectators)
+	return nil
+}
+
+func (t *PokerTable) RemoveSpectator(spectatorId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.removeSpectator(spectatorId) {
+		return ErrSpectatorNotFound
+	}
+	return nil
+}
+
+func (t *PokerTable) removeSpectator(spectatorId string) bool {
+	ind := slices.Index(t.Meta.Spectators, spectatorId)
+	if ind < 0 {
+		return false
+	}
+	t.Meta.Spectators = slices.Delete(t.Meta.Spectators, ind, ind+1)
+	t.Config.CurrentSpectators = len(t.Meta.Spectators)
+	return true
+}
+
+// public - получатели открытых событий стола: игроки и зрители
+func (t *PokerTable) public() []string {
+	return slices.Concat(t.Meta.PlayersOrder, t.Meta.Spectators)
+}
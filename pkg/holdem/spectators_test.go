@@ -0,0 +1,88 @@
+package holdem
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// inbox запоминает события, которые дошли до одного получателя
+type inbox struct {
+	id       string
+	messages []ObserverMessage
+}
+
+func (i *inbox) Update(recipients []string, data ObserverMessage) {
+	if slices.Contains(recipients, i.id) {
+		i.messages = append(i.messages, data)
+	}
+}
+
+func TestSpectators(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	config.Shuffler = NewCryptoShuffler()
+	table := NewPokerTable(config)
+	rec := &eventRecorder{}
+	hands := &[]HandHistory{}
+	table.AddObserver(rec)
+	table.AddObserver(NewHandRecorder(func(h HandHistory) { *hands = append(*hands, h) }))
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+	table.Config.MaxSpectators = 2
+	s1, s2 := uuid.NewString(), uuid.NewString()
+	require.ErrorIs(t, table.AddSpectator(p1.GetId()), ErrAlreadySeated)
+	require.NoError(t, table.AddSpectator(s1))
+	require.NoError(t, table.AddSpectator(s1))
+	require.NoError(t, table.AddSpectator(s2))
+	require.ErrorIs(t, table.AddSpectator(uuid.NewString()), ErrMaxSpectators)
+	require.Equal(t, table.Config.CurrentSpectators, 2)
+
+	spectator, player := &inbox{id: s1}, &inbox{id: p2.GetId()}
+	table.AddObserver(spectator)
+	table.AddObserver(player)
+	require.NoError(t, table.ShowCards(p2.GetId()))
+	require.NoError(t, table.MakeMove(p2.GetId(), "raise", 300))
+	require.NoError(t, table.MakeMove(p3.GetId(), "fold", 0))
+	require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+	require.NoError(t, table.StartGame())
+
+	// зритель получает то же, что игрок, кроме его карт, подсказок хода и сида раздачи
+	types := func(messages []ObserverMessage) []string {
+		output := []string{}
+		for _, m := range messages {
+			if !slices.Contains([]string{EventGetCards, EventCanDo, EventShuffleReveal}, m.EventType) {
+				output = append(output, m.EventType)
+			}
+		}
+		return output
+	}
+	require.Equal(t, types(spectator.messages), types(player.messages))
+	require.Contains(t, types(spectator.messages), EventShow)
+	for _, m := range spectator.messages {
+		require.NotContains(t, []string{EventGetCards, EventCanDo, EventBadMove, EventShuffleReveal}, m.EventType)
+	}
+	require.True(t, slices.ContainsFunc(player.messages, func(m ObserverMessage) bool { return m.EventType == EventShuffleReveal }))
+	require.Nil(t, (*hands)[0].ForPlayer(s1).Shuffle)
+	require.Len(t, rec.byType(EventGetCards), 3+3)
+	for _, p := range table.GetState(s1).Players {
+		require.Nil(t, p.Hand)
+	}
+
+	require.ErrorIs(t, table.RemoveSpectator(uuid.NewString()), ErrSpectatorNotFound)
+	require.NoError(t, table.RemoveSpectator(s2))
+	require.Equal(t, table.Config.CurrentSpectators, 1)
+
+	// зритель сел за стол и больше не считается зрителем
+	table.Config.EnterAfterStart = true
+	require.NoError(t, table.AddPlayer(&Player{Id: uuid.MustParse(s1), Balance: 1000}))
+	require.Empty(t, table.Meta.Spectators)
+	require.Equal(t, table.Config.CurrentSpectators, 0)
+}
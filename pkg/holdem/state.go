@@ -0,0 +1,139 @@
+package holdem
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+const ActionState = "state" // клиент просит снимок стола
+
+// SeatState - игрок в снимке стола. Hand приходит только самому игроку и у открывших карты
+type SeatState struct {
+	Id         string `json:"id"`
+	Seat       int    `json:"seat"`
+	Balance    int    `json:"balance"`
+	Bet        int    `json:"bet"`       // ставка на текущей улице
+	TotalBet   int    `json:"total_bet"` // сколько вложил в банк за раздачу
+	InHand     bool   `json:"in_hand"`   // получил карты и не сбросил их
+	AllIn      bool   `json:"all_in,omitempty"`
+	SittingOut bool   `json:"sitting_out,omitempty"`
+	Waiting    bool   `json:"waiting,omitempty"` // вошел во время раздачи и сядет со следующей
+	Hand       *Hand  `json:"hand,omitempty"`
+}
+
+// PotState - банк в снимке стола. Ставки текущей улицы попадут в банки в ее конце
+type PotState struct {
+	Amount  int      `json:"amount"`
+	Players []string `json:"players"`
+}
+
+// TableState - снимок стола для игрока, который вошел во время раздачи или переподключился.
+// Чужие карты скрыты, пока их не открыли
+type TableState struct {
+	Hand             int              `json:"hand"`
+	GameStarted      bool             `json:"game_started"`
+	Round            int              `json:"round"` // -1 до первой раздачи, 0 - пре-флоп
+	GameType         string           `json:"game_type"`
+	BettingStructure string           `json:"betting_structure"`
+	MaxPlayers       int              `json:"max_players"`
+	SmallBlind       int              `json:"small_blind"`
+	BigBlind         int              `json:"big_blind"`
+	Ante             int              `json:"ante"`
+	BombPot          bool             `json:"bomb_pot,omitempty"`
+	ButtonSeat       int              `json:"button_seat"`
+	SmallBlindSeat   int              `json:"small_blind_seat"` // -1 до первой раздачи
+	BigBlindSeat     int              `json:"big_blind_seat"`   // -1 до первой раздачи
+	Board            []Card           `json:"board"`
+	Boards           [][]Card         `json:"boards,omitempty"` // борды прогонов, если борд прогоняется несколько раз
+	Pots             []PotState       `json:"pots"`
+	CurrentBet       int              `json:"current_bet"`
+	Turn             string           `json:"turn,omitempty"` // чей ход
+	Clock            *ActionClock     `json:"clock,omitempty"`
+	CanDo            *AvailableAction `json:"can_do,omitempty"` // только если ход смотрящего
+	MinRaise         int              `json:"min_raise,omitempty"`
+	MaxRaise         int              `json:"max_raise,omitempty"`
+	RunIt            *RunItOffer      `json:"run_it,omitempty"` // идет голосование за прогоны
+	Players          []SeatState      `json:"players"`
+}
+
+// GetState возвращает снимок стола глазами viewerId: его карты видны, чужие - только открытые
+func (t *PokerTable) GetState(viewerId string) TableState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state := TableState{
+		Hand:             t.Meta.HandCount,
+		GameStarted:      t.Meta.GameStarted,
+		Round:            t.Meta.CurrentRound,
+		GameType:         t.Config.Rules().Name(),
+		BettingStructure: t.Config.BettingStructure,
+		MaxPlayers:       t.Config.MaxPlayers,
+		SmallBlind:       t.Config.SmallBlind,
+		BigBlind:         t.Config.SmallBlind * 2,
+		Ante:             t.Config.Ante,
+		BombPot:          t.Meta.BombPot,
+		ButtonSeat:       t.Meta.ButtonSeat,
+		SmallBlindSeat:   t.Meta.SmallBlindSeat,
+		BigBlindSeat:     t.Meta.BigBlindSeat,
+		Board:            t.Meta.CommunityCards,
+		CurrentBet:       t.Meta.CurrentBet,
+		Pots:             make([]PotState, 0, len(t.Meta.Pots)),
+		Players:          make([]SeatState, 0, len(t.Meta.Seats)),
+	}
+	if len(t.Meta.Boards) > 1 {
+		state.Boards = t.Meta.Boards
+	}
+	for _, pot := range t.Meta.Pots {
+		state.Pots = append(state.Pots, PotState{Amount: pot.Amount, Players: pot.Applicants})
+	}
+	for _, k := range t.Meta.PlayersOrder {
+		state.Players = append(state.Players, t.seatState(k, viewerId))
+	}
+	waiting := slices.SortedFunc(maps.Keys(t.Meta.Query), func(a, b string) int { return cmp.Compare(t.Meta.Seats[a], t.Meta.Seats[b]) })
+	for _, k := range waiting {
+		state.Players = append(state.Players, SeatState{Id: k, Seat: t.Meta.Seats[k], Balance: t.Meta.Query[k].GetBalance(), Waiting: true})
+	}
+	if !t.Meta.GameStarted {
+		return state
+	}
+	if t.runItPending() {
+		r := t.Meta.RunIt
+		state.RunIt = &RunItOffer{Players: r.Players, MaxRuns: r.MaxRuns, Deadline: r.Deadline}
+		return state
+	}
+	turn := t.Meta.PlayersOrder[t.Meta.PlayerTurnInd]
+	state.Turn = turn
+	if !t.Meta.TurnDeadline.IsZero() {
+		clock := t.clockInfo(turn)
+		state.Clock = &clock
+	}
+	if turn == viewerId {
+		action := t.availableAction(turn)
+		state.CanDo = &action
+		state.MinRaise, state.MaxRaise = t.raiseRange(t.Meta.Players[turn])
+	}
+	return state
+}
+
+// seatState - игрок за столом в снимке для viewerId
+func (t *PokerTable) seatState(playerId, viewerId string) SeatState {
+	p := t.Meta.Players[playerId]
+	dealt := t.Meta.GameStarted && len(p.GetHand().Cards) != 0
+	s := SeatState{
+		Id:         playerId,
+		Seat:       t.Meta.Seats[playerId],
+		Balance:    p.GetBalance(),
+		SittingOut: t.sittingOut(playerId),
+	}
+	if t.Meta.GameStarted {
+		s.Bet = p.GetLastBet()
+		s.TotalBet = p.GetTotalBet()
+		s.InHand = dealt && !p.GetFold()
+		s.AllIn = s.InHand && p.GetBalance() == 0
+	}
+	if (dealt && playerId == viewerId) || t.Meta.Shown[playerId] {
+		hand := p.GetHand()
+		s.Hand = &hand
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+package holdem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetState(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.StartGame()
+	require.NoError(t, table.MakeMove(p2.GetId(), "raise", 300))
+
+	state := table.GetState(p3.GetId())
+	require.True(t, state.GameStarted)
+	require.Equal(t, state.Round, 0)
+	require.Equal(t, state.CurrentBet, 300)
+	require.Equal(t, state.Turn, p3.GetId())
+	require.Equal(t, state.CanDo, &AvailableAction{PlayerId: p3.GetId(), Action: "call", Amount: 300})
+	require.Equal(t, state.MinRaise, 500)
+	require.Equal(t, state.MaxRaise, 1000)
+	require.Equal(t, state.Players, []SeatState{
+		{Id: p1.GetId(), Seat: 0, Balance: 900, Bet: 100, TotalBet: 100, InHand: true},
+		{Id: p2.GetId(), Seat: 1, Balance: 700, Bet: 300, TotalBet: 300, InHand: true},
+		{Id: p3.GetId(), Seat: 2, Balance: 950, Bet: 50, TotalBet: 50, InHand: true, Hand: &p3.Hand},
+	})
+
+	// чужой ход: можно только смотреть, свои карты видны и после сброса
+	state = table.GetState(p1.GetId())
+	require.Nil(t, state.CanDo)
+	require.Zero(t, state.MinRaise)
+	require.NoError(t, table.MakeMove(p3.GetId(), "fold", 0))
+	state = table.GetState(p3.GetId())
+	require.False(t, state.Players[2].InHand)
+	require.Equal(t, state.Players[2].Hand, &p3.Hand)
+
+	// тот, кто не за столом, не видит ничьих карт
+	for _, p := range table.GetState("").Players {
+		require.Nil(t, p.Hand)
+	}
+
+	require.NoError(t, table.MakeMove(p1.GetId(), "call", 0))
+	state = table.GetState("")
+	require.Equal(t, state.Round, 1)
+	require.Len(t, state.Board, 3)
+	require.Equal(t, state.Pots, []PotState{{Amount: 650, Players: state.Pots[0].Players}})
+	require.ElementsMatch(t, state.Pots[0].Players, []string{p1.GetId(), p2.GetId()})
+	require.Equal(t, state.Players[1].Bet, 0)
+
+	// после раздачи видны только открытые карты
+	require.NoError(t, table.ShowCards(p2.GetId()))
+	require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+	state = table.GetState(p1.GetId())
+	require.False(t, state.GameStarted)
+	require.Empty(t, state.Turn)
+	require.Nil(t, state.Players[0].Hand)
+	require.Equal(t, state.Players[1].Hand, &p2.Hand)
+	require.Equal(t, state.Players[1].Balance, 1000+350)
+}
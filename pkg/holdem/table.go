@@ -3,7 +3,7 @@ package holdem
 import (
 	"errors"
 	"fmt"
-	"math/rand"
+	"maps"
 	"slices"
 	"sync"
 	"time"
@@ -19,7 +19,9 @@ var (
 	ErrNotYourTurn      = errors.New("not your turn t")
 	ErrPlayerIsFold     = errors.New("player already fold his cards")
 	ErrCantCheck        = errors.New("you cant check")
-	ErrCantRaise        = errors.New("raise must exceed the current bet by at least two times")
+	ErrCantRaise        = errors.New("raise must be at least the size of the previous raise")
+	ErrCantBet          = errors.New("bet already made, you can only raise")
+	ErrActionClosed     = errors.New("action was not reopened, you can only call or fold")
 	ErrNotEnoughMoney   = errors.New("not enough money for this action")
 	ErrUnexpectedAction = errors.New("unexpected action")
 	ErrPlayerNotFound   = errors.New("player not found")
@@ -29,8 +31,19 @@ type IPokerTable interface {
 	StartGame() error
 	AddObserver(o IObserver)
 	AddPlayer(player IPlayer) error
+	AddPlayerAt(player IPlayer, seat int) error
 	RemovePlayer(playerId string) error
+	Leave(playerId string) error
 	MakeMove(playerId, action string, amount int) error
+	SetClientSeed(playerId, seed string) error
+	RunItVote(playerId string, runs int) error
+	RequestBombPot(playerId string) error
+	SitOut(playerId string) error
+	SitIn(playerId string, postMissed bool) error
+	ShowCards(playerId string) error
+	GetState(viewerId string) TableState
+	AddSpectator(spectatorId string) error
+	RemoveSpectator(spectatorId string) error
 	GetConfig() *TableConfig
 	CheckPlayer(playerId string) bool
 	GetPlayerList() []string
@@ -45,40 +58,94 @@ type TableConfig struct {
 	MaxPlayers        int           `json:"max_players"`
 	MinPlayers        int           `json:"min_players_to_start"`
 	CurrentPlayers    int           `json:"current_players_count"`
+	MaxSpectators     int           `json:"max_spectators"` // 0 - без ограничения
+	CurrentSpectators int           `json:"current_spectators_count"`
 	EnterAfterStart   bool          `json:"cache_game"` //true = cache game. false = sit n go
 	SmallBlind        int           `json:"small_blind"`
 	Ante              int           `json:"ante"`
 	BankAmount        int           `json:"bank_amount"`
-	Seed              int64         `json:"-"`
+	BlindStructure    string        `json:"blind_structure"`
+	BlindLevels       []BlindLevel  `json:"blind_levels,omitempty"`
+	BlindLevel        int           `json:"blind_level"`
+	GameType          string        `json:"game_type"`         // holdem, omaha, omaha_hilo, short_deck или другие из RegisterGameRules
+	BettingStructure  string        `json:"betting_structure"` // no_limit, pot_limit, fixed_limit
+	RaiseCap          int           `json:"raise_cap"`         // максимум bet + рейзов на улице в fixed limit
+	ButtonBlind       bool          `json:"button_blind"`      // вместо малого и большого блайнда дилер ставит блайнд размером с большой
+	MoveTimeout       time.Duration `json:"move_timeout"`      // 0 = без ограничения по времени
+	TimeBank          time.Duration `json:"time_bank"`         // восполняется перед каждой раздачей
+	RunItMax          int           `json:"run_it_max"`        // сколько раз можно прогнать борд, когда все в all in. 0 - нельзя
+	Straddle          bool          `json:"straddle"`          // игрок после большого блайнда ставит страддл в два больших блайнда
+	BombPotAnte       int           `json:"bomb_pot_ante"`     // анте бомб-пота. 0 - бомб-потов нет
+	BombPotEvery      int           `json:"bomb_pot_every"`    // каждая какая раздача - бомб-пот. 0 - только по просьбе хоста
+	HostId            uuid.UUID     `json:"host_id"`           // создатель лобби
+	RakePercent       float64       `json:"rake_percent"`      // процент рейка с банка
+	RakeCap           int           `json:"rake_cap"`          // максимум рейка с раздачи. 0 - без ограничения
+	NoFlopNoDrop      bool          `json:"no_flop_no_drop"`   // не брать рейк, если раздача закончилась до флопа
+	SitOutTimeouts    int           `json:"sit_out_timeouts"`  // после скольких ходов по таймеру подряд игрок пропускает раздачи. 0 - никогда
+	Shuffler          IShuffler     `json:"-"`
 }
 
-// TODO add timeout for 1 move and time bank
 type TableMeta struct {
-	DealerIndex    int
-	PlayerTurnInd  int
-	CurrentBet     int
-	CommunityCards []Card
-	PlayersOrder   []string
-	Players        map[string]IPlayer
-	Query          map[string]IPlayer
-	Pots           []Pot
-	Deck           []Card
-	CurrentRound   int
-	GameStarted    bool
+	ButtonSeat      int // место баттона, может быть пустым (мертвый баттон)
+	SmallBlindSeat  int // место малого блайнда. Если там никого нет, малый блайнд не ставится
+	BigBlindSeat    int // -1 до первой раздачи
+	PlayerTurnInd   int
+	CurrentBet      int
+	LastRaise       int             // размер последнего полного рейза на улице, минимальный рейз не может быть меньше
+	RaisesCount     int             // bet и полные рейзы на улице, нужно для ограничения в fixed limit
+	LastAggressor   string          // последний, кто ставил или повышал на улице, он первым открывает карты
+	ShowRequests    map[string]bool // попросили открыть карты в конце раздачи
+	Shown           map[string]bool // чьи карты открыты в этой раздаче
+	CommunityCards  []Card
+	Boards          [][]Card // борды прогонов, если борд прогоняется несколько раз. CommunityCards - первый из них
+	RunIt           *RunIt
+	BombPot         bool // текущая раздача - бомб-пот
+	BombPotNext     bool // хост попросил бомб-пот в следующей раздаче
+	Flopped         bool // до флопа дошли хотя бы двое, для no flop no drop
+	SittingOut      map[string]*SitOut
+	Timeouts        map[string]int // ходы по таймеру подряд
+	DeadBlinds      []string       // вернувшиеся в этой раздаче с пропущенным блайндом
+	Seats           map[string]int // место игрока от 0 до MaxPlayers-1, в том числе ждущих в Query
+	PlayersOrder    []string       // по порядку мест
+	Players         map[string]IPlayer
+	Query           map[string]IPlayer
+	Departed        map[string]IPlayer // вышли из-за стола посреди раздачи, их фишки остаются в банке
+	Leaving         map[string]bool    // уходят, место освободится после раздачи
+	Spectators      []string           // получают открытые события, но не сидят за столом
+	Dealt           []string           // кому раздали карты в последней раздаче
+	Remaining       []string           // не сбросили карты к концу последней раздачи и могут открыть их до начала следующей
+	Pots            []Pot
+	Deck            []Card
+	CurrentRound    int
+	GameStarted     bool
+	HandCount       int
+	HandDeck        []Card        // колода раздачи сразу после перемешивания, по ней раздачу можно повторить
+	Shuffle         *ShuffleProof // раскрывается после раздачи, nil если перемешивание нельзя проверить
+	ClientSeeds     map[string]string
+	TurnId          int // растет с каждой передачей хода, чтобы отличать устаревшие таймеры
+	TurnDeadline    time.Time
+	TimeBankStarted time.Time // не нулевое, если игрок сейчас тратит банк времени
+	TimeBanks       map[string]time.Duration
 }
 
 type PokerTable struct {
 	observers []IObserver
 	mu        sync.Mutex
+	clock     *time.Timer
 	Config    *TableConfig
 	Meta      *TableMeta
 }
 
+// NewTableConfig создает конфиг стола. С ненулевым seed колода перемешивается SeededShuffler, иначе CryptoShuffler
 func NewTableConfig(
 	BlindIncreaseTime time.Duration,
 	maxPlayers, minPlayers, smallBlind, ante, bankAmount int,
 	enterAfteStart bool,
 	seed int64) *TableConfig {
+	var shuffler IShuffler = NewCryptoShuffler()
+	if seed != 0 {
+		shuffler = NewSeededShuffler(seed)
+	}
 	return &TableConfig{
 		BlindIncreaseTime: BlindIncreaseTime,
 		LastBlindIncrease: time.Now(),
@@ -88,29 +155,46 @@ func NewTableConfig(
 		EnterAfterStart:   enterAfteStart,
 		SmallBlind:        smallBlind,
 		Ante:              ante,
-		Seed:              seed,
+		Shuffler:          shuffler,
 		BankAmount:        bankAmount,
+		GameType:          GameHoldem,
+		BettingStructure:  BettingNoLimit,
+		RaiseCap:          DefaultRaiseCap,
 		TableId:           uuid.New(),
 	}
 }
 
 func NewTableMeta() *TableMeta {
 	return &TableMeta{
-		DealerIndex:    0,
+		ButtonSeat:     0,
+		SmallBlindSeat: -1,
+		BigBlindSeat:   -1,
 		PlayerTurnInd:  0,
 		CurrentBet:     0,
 		CommunityCards: []Card{},
+		Seats:          make(map[string]int),
 		PlayersOrder:   make([]string, 0, 10),
 		Players:        make(map[string]IPlayer),
 		Query:          make(map[string]IPlayer),
+		Departed:       make(map[string]IPlayer),
+		Leaving:        make(map[string]bool),
 		Pots:           []Pot{},
 		Deck:           []Card{},
 		CurrentRound:   -1,
 		GameStarted:    false,
+		TimeBanks:      make(map[string]time.Duration),
+		ClientSeeds:    make(map[string]string),
+		SittingOut:     make(map[string]*SitOut),
+		Timeouts:       make(map[string]int),
+		ShowRequests:   make(map[string]bool),
+		Shown:          make(map[string]bool),
 	}
 }
 
 func NewPokerTable(config *TableConfig) *PokerTable {
+	if config.Shuffler == nil {
+		config.Shuffler = NewCryptoShuffler()
+	}
 	return &PokerTable{
 		observers: []IObserver{},
 		mu:        sync.Mutex{},
@@ -137,15 +221,17 @@ func (t *PokerTable) CheckPlayer(playerId string) bool {
 	return false
 }
 
-func (m *TableMeta) refreshDeck(seed int64) {
-	m.Deck = GetStandardDeck()
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	if seed != 0 {
-		r = rand.New(rand.NewSource(seed))
+// refreshDeck перемешивает новую колоду с сидами игроков, которые сейчас за столом
+func (m *TableMeta) refreshDeck(deck []Card, shuffler IShuffler) {
+	clientSeeds := []ClientSeed{}
+	for _, k := range m.PlayersOrder {
+		if seed, ok := m.ClientSeeds[k]; ok {
+			clientSeeds = append(clientSeeds, ClientSeed{PlayerId: k, Seed: seed})
+		}
 	}
-	r.Shuffle(len(m.Deck), func(i, j int) {
-		m.Deck[i], m.Deck[j] = m.Deck[j], m.Deck[i]
-	})
+	m.Shuffle = shuffler.Shuffle(deck, clientSeeds)
+	m.Deck = deck
+	m.HandDeck = slices.Clone(deck)
 }
 
 func (m *TableMeta) addPlayerInGame(p IPlayer, bankAmount int) {
@@ -172,41 +258,112 @@ func (t *PokerTable) NotifyObservers(recipients []string, data ObserverMessage)
 }
 
 func (t *PokerTable) AddPlayer(p IPlayer) error {
+	return t.AddPlayerAt(p, AnySeat)
+}
+
+// AddPlayerAt сажает игрока на выбранное место от 0 до MaxPlayers-1, AnySeat - на первое свободное
+func (t *PokerTable) AddPlayerAt(p IPlayer, seat int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.CheckPlayer(p.GetId()) {
+		return ErrAlreadySeated
+	}
 	if t.Meta.GameStarted && !t.Config.EnterAfterStart {
 		return ErrGameStarted
 	}
-
-	if t.Config.MaxPlayers <= len(t.Meta.Players)+len(t.Meta.Query)+1 {
-		return ErrMaxPlayers
+	if err := t.takeSeat(p.GetId(), seat); err != nil {
+		return err
 	}
+	t.removeSpectator(p.GetId()) // зритель сел за стол
 
 	if t.Meta.GameStarted {
 		t.Meta.addPlayerInQuery(p)
 	} else {
 		t.Meta.addPlayerInGame(p, t.Config.BankAmount)
 		t.Meta.PlayersOrder = append(t.Meta.PlayersOrder, p.GetId())
+		t.sortBySeat()
 	}
 	t.Config.CurrentPlayers += 1
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"player_enter", fmt.Sprintf("player %s enter the game", p.GetId()), t.Config.TableId.String()})
+	t.notify(t.public(), EventPlayerEnter, PlayerEntered{PlayerId: p.GetId(), Seat: t.Meta.Seats[p.GetId()]})
+	if commitment := t.Config.Shuffler.Commit(); commitment != "" {
+		t.notify([]string{p.GetId()}, EventShuffleCommit, ShuffleCommitted{Hand: t.Meta.HandCount + 1, Commitment: commitment})
+	}
 	return nil
 }
 
+// notifySeats рассылает рассадку и стеки игроков перед анте и блайндами
+func (t *PokerTable) notifySeats() {
+	players := make([]PlayerStats, 0, len(t.Meta.PlayersOrder))
+	for _, k := range t.Meta.PlayersOrder {
+		players = append(players, PlayerStats{Id: k, Seat: t.Meta.Seats[k], Balance: t.Meta.Players[k].GetBalance(), SittingOut: t.sittingOut(k)})
+	}
+	t.notify(t.public(), EventSeats, Seats{
+		Hand:             t.Meta.HandCount,
+		GameType:         t.Config.Rules().Name(),
+		BettingStructure: t.Config.BettingStructure,
+		MaxPlayers:       t.Config.MaxPlayers,
+		SmallBlind:       t.Config.SmallBlind,
+		BigBlind:         t.Config.SmallBlind * 2,
+		Ante:             t.Config.Ante,
+		Players:          players,
+	})
+	bombPotAnte := 0
+	if t.Meta.BombPot {
+		bombPotAnte = t.Config.BombPotAnte
+	}
+	var blindSeats []int
+	if t.Meta.BigBlindSeat >= 0 {
+		blindSeats = []int{t.Meta.SmallBlindSeat, t.Meta.BigBlindSeat}
+	}
+	// без получателей: по сиду можно узнать чужие карты, событие нужно только наблюдателям на сервере
+	t.notify(nil, EventReplayState, ReplayState{
+		Deck:         t.Meta.HandDeck,
+		DealerIndex:  t.Meta.ButtonSeat,
+		BlindSeats:   blindSeats,
+		RaiseCap:     t.Config.RaiseCap,
+		ButtonBlind:  t.Config.ButtonBlind,
+		RunItMax:     t.Config.RunItMax,
+		Straddle:     t.Config.Straddle,
+		BombPot:      bombPotAnte,
+		RakePercent:  t.Config.RakePercent,
+		RakeCap:      t.Config.RakeCap,
+		NoFlopNoDrop: t.Config.NoFlopNoDrop,
+		DeadBlinds:   t.Meta.DeadBlinds,
+	})
+}
+
 func (t *PokerTable) enterPlayersFromQuery() {
 	for k, v := range t.Meta.Query {
 		t.Meta.Players[k] = v
 		t.Meta.PlayersOrder = append(t.Meta.PlayersOrder, k)
 	}
+	t.sortBySeat()
 }
 
 func (t *PokerTable) StartGame() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	fmt.Println("start game poker level")
 	if t.Meta.GameStarted {
 		return ErrGameStarted
 	}
+	if !t.canStart() {
+		return ErrNotEnoughPlayers
+	}
 	t.Meta.GameStarted = true
 	t.Meta.CurrentRound = -1
-	t.Meta.refreshDeck(t.Config.Seed)
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"game_started", fmt.Sprintf("game %s started", t.Config.TableId.String()), t.Config.TableId.String()})
+	if t.Meta.HandCount == 0 && !t.Config.EnterAfterStart {
+		// в sit n go уровни блайндов отсчитываются от начала турнира, а не от создания лобби
+		t.Config.LastBlindIncrease = time.Now()
+	}
+	t.Meta.HandCount++
+	t.scheduleBombPot()
+	t.Meta.refreshDeck(t.Config.Rules().Deck(), t.Config.Shuffler)
+	if t.Meta.Shuffle != nil {
+		t.Meta.Shuffle.Hand = t.Meta.HandCount
+		t.Meta.Shuffle.GameType = t.Config.Rules().Name()
+	}
+	t.notify(t.public(), EventGameStarted, GameStarted{Hand: t.Meta.HandCount})
 	t.SendPlayersStats(false)
 	t.NewRound()
 	return nil
@@ -218,15 +375,17 @@ func (t *PokerTable) GetPlayerList() []string {
 
 func (t *PokerTable) SendPlayersStats(withCards bool) {
 	fmt.Println(t.Meta.CurrentRound)
-	output := make([]map[string]any, 0, len(t.Meta.Players))
-	for _, v := range t.Meta.Players {
-		output = append(output, v.ToJson(withCards))
-	}
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{
-		"players_stats",
-		output,
-		t.Config.TableId.String(),
-	})
+	output := make([]PlayerStats, 0, len(t.Meta.Players))
+	for _, k := range t.Meta.PlayersOrder {
+		v := t.Meta.Players[k]
+		stats := PlayerStats{Id: v.GetId(), Seat: t.Meta.Seats[k], Balance: v.GetBalance(), SittingOut: t.sittingOut(k)}
+		if withCards && t.Meta.Shown[k] {
+			hand := v.GetHand()
+			stats.Hand = &hand
+		}
+		output = append(output, stats)
+	}
+	t.notify(t.public(), EventPlayersStats, PlayersStats{Players: output})
 }
 
 func (t *PokerTable) NewRound() error {
@@ -234,48 +393,87 @@ func (t *PokerTable) NewRound() error {
 		return ErrGameNotStarted
 	}
 	t.resetPlayersStatus()
+	t.returnUncalledBet()
 	t.createPots()
+	if t.offerRunIt() {
+		return nil
+	}
 	t.Meta.CurrentRound += 1
 	t.Meta.CurrentBet = 0
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"new_round", fmt.Sprintf("new round started. Current round: %d", t.Meta.CurrentRound), t.Config.TableId.String()})
+	t.Meta.LastRaise = t.betSize()
+	t.Meta.RaisesCount = 0
+	t.notify(t.public(), EventNewRound, RoundStarted{Round: t.Meta.CurrentRound})
 	refreshPlayers(t.Meta.Players, false)
-	switch t.Meta.CurrentRound {
-	case 0: //pre flop
+	refreshPlayers(t.Meta.Departed, false)
+	rules := t.Config.Rules()
+	streets := rules.Streets()
+	switch {
+	case t.Meta.CurrentRound == 0: //pre flop
+		t.Meta.CommunityCards = []Card{}
+		t.Meta.LastAggressor = ""
+		clear(t.Meta.ShowRequests)
+		clear(t.Meta.Shown)
+		clear(t.Meta.Departed)
 		t.enterPlayersFromQuery()
+		t.refillTimeBanks()
+		t.updateBlindLevel()
+		t.seatPlayers()
+		t.notifySeats()
 		t.betAnte()
+		t.betDeadBlinds()
+		t.Meta.Dealt = nil
+		t.Meta.Remaining = nil
 		for _, k := range t.Meta.PlayersOrder {
-			cards, _ := t.drawCard(2)
-			t.Meta.Players[k].SetHand(Hand{[2]Card{cards[0], cards[1]}})
-			t.NotifyObservers([]string{k}, ObserverMessage{"get_cards", fmt.Sprintf("player %s get cards: %v", t.Meta.Players[k].GetId(), cards), t.Config.TableId.String()})
+			if t.sittingOut(k) {
+				continue
+			}
+			t.Meta.Dealt = append(t.Meta.Dealt, k)
+			cards, _ := t.drawCard(rules.HoleCards())
+			t.Meta.Players[k].SetHand(Hand{Cards: cards})
+			t.notify([]string{k}, EventGetCards, CardsDealt{PlayerId: k, Cards: cards})
 		}
 		t.choiceDealer()
-		t.betBlinds()
-	case 1: // flop
-		t.Meta.CommunityCards, _ = t.drawCard(3)
-		t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"community_cards", fmt.Sprintf("community cards: %v", t.Meta.CommunityCards), t.Config.TableId.String()})
-		t.Meta.PlayerTurnInd = (t.Meta.DealerIndex + 1) % len(t.Meta.PlayersOrder)
-
-	case 2: // turn
-		cards, _ := t.drawCard(1)
-		t.Meta.CommunityCards = append(t.Meta.CommunityCards, cards...)
-		t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"community_cards", fmt.Sprintf("community cards: %v", t.Meta.CommunityCards), t.Config.TableId.String()})
-
-	case 3: // river
-		cards, _ := t.drawCard(1)
-		t.Meta.CommunityCards = append(t.Meta.CommunityCards, cards...)
-		t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"community_cards", fmt.Sprintf("community cards: %v", t.Meta.CommunityCards), t.Config.TableId.String()})
-
-	case 4: // determinate winner
+		if t.Meta.BombPot {
+			t.betBombPot()
+		} else {
+			t.betBlinds()
+		}
+
+	case t.Meta.CurrentRound <= len(streets): // flop, turn, river
+		if t.Meta.CurrentRound == 1 {
+			t.markFlop()
+		}
+		t.Meta.LastAggressor = ""
+		t.dealStreet(streets[t.Meta.CurrentRound-1])
+
+	default: // determinate winner
+		t.stopClock()
+		t.showdown()
+		for _, k := range t.Meta.Dealt {
+			if p, ok := t.Meta.Players[k]; ok && !p.GetFold() {
+				t.Meta.Remaining = append(t.Meta.Remaining, k)
+			}
+		}
 		t.PayMoney()
-		t.Config.updateSeed()
 		t.Meta.GameStarted = false
 		t.Meta.CurrentRound = -1
 		t.Meta.Pots = t.Meta.Pots[:0]
+		t.Meta.Boards = nil
+		t.Meta.RunIt = nil
+		t.Meta.BombPot = false
+		t.Meta.Flopped = false
 		refreshPlayers(t.Meta.Players, true)
-		t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"stop_game", fmt.Sprintf("game %s has been stopped", t.Config.TableId.String()), t.Config.TableId.String()})
+		for k := range t.Meta.Leaving {
+			t.removePlayer(k)
+		}
+		clear(t.Meta.Leaving)
+		t.notify(t.public(), EventStopGame, GameStopped{Hand: t.Meta.HandCount})
+		t.revealShuffle()
 	}
 	t.choiceFirstMovePlayer()
-	t.notifyNext()
+	if !t.bombPotPreflop() {
+		t.notifyNext()
+	}
 	t.SendPlayersStats(t.Meta.CurrentRound == -1)
 	if t.checkReady() {
 		t.NewRound()
@@ -283,20 +481,6 @@ func (t *PokerTable) NewRound() error {
 	return nil
 }
 
-func (cfg *TableConfig) updateSeed() {
-	if cfg.Seed != 0 {
-		r := rand.New(rand.NewSource(cfg.Seed))
-		for {
-			newSeed := r.Int63()
-			if newSeed == 0 {
-				continue
-			}
-			cfg.Seed = newSeed
-			break
-		}
-	}
-}
-
 func (t *PokerTable) PayMoney() {
 	active := ""
 	flag := true
@@ -312,44 +496,69 @@ func (t *PokerTable) PayMoney() {
 		}
 		active = k
 	}
+	// рейк вычитается из банков до выплат
+	rake := t.potsRake()
 	if flag {
-		winSum := 0
-		for _, pot := range t.Meta.Pots {
-			winSum += pot.Amount
+		winSum, rakeSum := 0, 0
+		for ind, pot := range t.Meta.Pots {
+			winSum += pot.Amount - rake[ind]
+			rakeSum += rake[ind]
 		}
 		t.Meta.Players[active].ChangeBalance(winSum)
-		t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"win_all", fmt.Sprintf("player %s win all pots with %d total amount", active, winSum), t.Config.TableId.String()})
+		t.notify(t.public(), EventWinAll, AllPotsWon{PlayerId: active, Amount: winSum, Rake: rakeSum})
 		return
 	}
+	boards := t.showdownBoards()
 	for ind, pot := range t.Meta.Pots {
-		applicants := make(map[string]IPlayer)
-		for _, k := range pot.Applicants {
-			p := t.Meta.Players[k]
-			if p.GetFold() { // если игрок сбросил то он не претендует на банк
-				continue
+		applicants := t.potApplicants(pot) // если игрок сбросил то он не претендует на банк
+		// при нескольких прогонах банк делится между бордами поровну, нечетные фишки - первым бордам
+		for r, board := range boards {
+			boardAmount := splitAmount(pot.Amount-rake[ind], len(boards), r)
+			boardRake := splitAmount(rake[ind], len(boards), r)
+			shares := t.Config.Rules().Showdown(board, applicants)
+			for i, share := range shares {
+				award := PotAwarded{Pot: ind + 1, Share: share.Name, Rake: splitAmount(boardRake, len(shares), i)}
+				if len(boards) > 1 {
+					award.Run = r + 1
+				}
+				t.awardPot(award, splitAmount(boardAmount, len(shares), i), share.Winners)
 			}
-			applicants[k] = p
-		}
-		winners, _ := DeterminateWinner(t.Meta.CommunityCards, applicants)
-		winAmount := pot.Amount / len(winners)
-		for _, winner := range winners {
-			t.Meta.Players[winner].ChangeBalance(winAmount)
 		}
-		t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"win_pot", fmt.Sprintf("winners of pot %.2d with %d amount: %v", ind+1, winAmount, winners), t.Config.TableId.String()})
-		if winAmount*len(winners) == pot.Amount {
+	}
+	t.SendPlayersStats(false)
+}
+
+// splitAmount - доля i из n частей amount. Остаток достается первым долям по одной фишке
+func splitAmount(amount, n, i int) int {
+	part := amount / n
+	if i < amount%n {
+		part++
+	}
+	return part
+}
+
+// awardPot делит amount между победителями. Остаток раздается по одной фишке начиная слева от дилера
+func (t *PokerTable) awardPot(award PotAwarded, amount int, winners []string) {
+	winners = slices.Sorted(slices.Values(winners)) // победители собираются из map, порядок должен быть одинаковым при повторе
+	winAmount := amount / len(winners)
+	for _, winner := range winners {
+		t.Meta.Players[winner].ChangeBalance(winAmount)
+	}
+	award.Amount = winAmount
+	award.Winners = winners
+	t.notify(t.public(), EventWinPot, award)
+	if winAmount*len(winners) == amount {
+		return
+	}
+	counter := amount - winAmount*len(winners)
+	for i := 1; counter > 0; i++ {
+		targetPlayer := t.Meta.PlayersOrder[t.afterSeat(t.Meta.ButtonSeat, i)]
+		if t.Meta.Players[targetPlayer].GetFold() || !slices.Contains(winners, t.Meta.Players[targetPlayer].GetId()) {
 			continue
 		}
-		counter := pot.Amount - winAmount*len(winners)
-		for i := 1; counter > 0; i++ {
-			targetPlayer := t.Meta.PlayersOrder[(t.Meta.DealerIndex+i)%len(t.Meta.Players)]
-			if t.Meta.Players[targetPlayer].GetFold() || !slices.Contains(winners, t.Meta.Players[targetPlayer].GetId()) {
-				continue
-			}
-			t.Meta.Players[targetPlayer].ChangeBalance(1)
-			counter--
-		}
+		t.Meta.Players[targetPlayer].ChangeBalance(1)
+		counter--
 	}
-	t.SendPlayersStats(false)
 }
 
 func (t *PokerTable) createPots() error {
@@ -357,13 +566,95 @@ func (t *PokerTable) createPots() error {
 		return ErrGameNotStarted
 	}
 
-	pots := CreatePots(t.Meta.Players)
-	t.Meta.Pots = append(t.Meta.Pots, pots...)
+	t.Meta.Pots = CreatePots(t.contributors())
 
 	return nil
 }
 
+// contributors - все, чьи фишки есть в банке раздачи, включая вышедших из-за стола
+func (t *PokerTable) contributors() map[string]IPlayer {
+	players := maps.Clone(t.Meta.Players)
+	maps.Copy(players, t.Meta.Departed)
+	return players
+}
+
+// returnUncalledBet возвращает игроку ту часть ставки на улице, которую никто не уравнял
+func (t *PokerTable) returnUncalledBet() {
+	var top IPlayer
+	second := 0
+	for _, v := range t.contributors() {
+		if top == nil || v.GetLastBet() > top.GetLastBet() {
+			if top != nil {
+				second = top.GetLastBet()
+			}
+			top = v
+			continue
+		}
+		second = max(second, v.GetLastBet())
+	}
+	if top == nil || top.GetFold() || top.GetLastBet() <= second { // фишки сбросившего остаются в банке
+		return
+	}
+	excess := top.GetLastBet() - second
+	top.ChangeBalance(excess)
+	top.SetLastBet(second)
+	top.SetTotalBet(top.GetTotalBet() - excess)
+	t.notify(t.public(), EventReturnBet, BetReturned{PlayerId: top.GetId(), Amount: excess})
+}
+
+// putChips переносит фишки игрока из стека в ставку текущей улицы
+func (t *PokerTable) putChips(p IPlayer, amount int) {
+	p.ChangeBalance(-amount)
+	p.SetLastBet(p.GetLastBet() + amount)
+	p.SetTotalBet(p.GetTotalBet() + amount)
+}
+
+// needsToAct показывает, ждет ли стол хода от игрока на текущей улице
+func (t *PokerTable) needsToAct(p IPlayer) bool {
+	if p.GetFold() || p.GetBalance() == 0 { // сбросил или уже all in
+		return false
+	}
+	return !p.GetReadyStatus() || p.GetLastBet() < t.Meta.CurrentBet
+}
+
+// RemovePlayer убирает игрока из-за стола. Если сейчас его ход, за него делается fold и ход переходит дальше
 func (t *PokerTable) RemovePlayer(playerId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Meta.GameStarted && !t.runItPending() && t.Meta.PlayersOrder[t.Meta.PlayerTurnInd] == playerId {
+		t.stopClock()
+		t.makeMove(playerId, "fold", 0)
+	}
+	if err := t.removePlayer(playerId); err != nil {
+		return err
+	}
+	// за столом мог остаться один не сбросивший карты
+	if !t.runItPending() && t.checkReady() {
+		t.NewRound()
+	}
+	return nil
+}
+
+// Leave выводит игрока из-за стола. Во время раздачи он сбрасывает карты, как только до него дойдет ход,
+// и пересаживается в сторону, а место освобождается, когда раздача закончится
+func (t *PokerTable) Leave(playerId string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.Meta.Players[playerId]; !ok || !t.Meta.GameStarted {
+		return t.removePlayer(playerId)
+	}
+	t.Meta.Leaving[playerId] = true
+	if !t.sittingOut(playerId) {
+		t.sitOut(playerId, SitOutLeaving)
+	}
+	if !t.runItPending() && t.Meta.PlayersOrder[t.Meta.PlayerTurnInd] == playerId {
+		t.stopClock()
+		t.makeMove(playerId, "fold", 0)
+	}
+	return nil
+}
+
+func (t *PokerTable) removePlayer(playerId string) error {
 	_, ok1 := t.Meta.Players[playerId]
 	_, ok2 := t.Meta.Query[playerId]
 	if !(ok1 || ok2) {
@@ -371,12 +662,30 @@ func (t *PokerTable) RemovePlayer(playerId string) error {
 	}
 	if ok2 {
 		delete(t.Meta.Query, playerId)
+		delete(t.Meta.ClientSeeds, playerId)
+		delete(t.Meta.Seats, playerId)
 		return nil
 	}
+	if p := t.Meta.Players[playerId]; t.Meta.GameStarted && p.GetTotalBet() > 0 {
+		// вложенное в банк остается в нем мертвыми деньгами
+		p.SetFold(true)
+		t.Meta.Departed[playerId] = p
+	}
 	delete(t.Meta.Players, playerId)
+	delete(t.Meta.ClientSeeds, playerId)
+	delete(t.Meta.SittingOut, playerId)
+	delete(t.Meta.Timeouts, playerId)
+	delete(t.Meta.Seats, playerId)
 	t.Config.CurrentPlayers -= 1
 	ind := slices.Index(t.Meta.PlayersOrder, playerId)
 	t.Meta.PlayersOrder = append(t.Meta.PlayersOrder[:ind], t.Meta.PlayersOrder[ind+1:]...)
+	// ход остается за тем же игроком
+	if ind < t.Meta.PlayerTurnInd {
+		t.Meta.PlayerTurnInd--
+	}
+	if t.Meta.PlayerTurnInd >= len(t.Meta.PlayersOrder) {
+		t.Meta.PlayerTurnInd = 0
+	}
 	return nil
 }
 
@@ -386,19 +695,29 @@ func (t *PokerTable) betAnte() error {
 	}
 	//TODO check if not 0 round
 	toRemove := []string{}
-	for k, v := range t.Meta.Players {
-		if v.GetBalance() < t.Config.Ante {
+	for _, k := range t.Meta.PlayersOrder { // по порядку мест, чтобы раздачу можно было повторить
+		v := t.Meta.Players[k]
+		if t.sittingOut(k) {
+			continue
+		}
+		if v.GetBalance() == 0 || v.GetBalance() < t.Config.Ante {
 			v.GetFold()
-			t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"cant_ante", fmt.Sprintf("player %s cant bet ante", k), t.Config.TableId.String()})
+			t.notify(t.public(), EventCantAnte, AnteFailed{PlayerId: k})
 			toRemove = append(toRemove, k)
 		}
 	}
 	for _, id := range toRemove {
-		t.RemovePlayer(id)
+		t.removePlayer(id)
 	}
 
-	t.Meta.Pots = append(t.Meta.Pots, Pot{Amount: t.Config.Ante * len(t.Meta.Players), Applicants: t.Meta.PlayersOrder})
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"get_ante", fmt.Sprintf("get ante: %d", t.Config.Ante*len(t.Meta.Players)), t.Config.TableId.String()})
+	for k, v := range t.Meta.Players {
+		if t.sittingOut(k) {
+			continue
+		}
+		v.ChangeBalance(-t.Config.Ante)
+		v.SetTotalBet(v.GetTotalBet() + t.Config.Ante)
+	}
+	t.notify(t.public(), EventGetAnte, AnteCollected{Ante: t.Config.Ante, Total: t.Config.Ante * t.activePlayers()})
 	return nil
 }
 
@@ -406,25 +725,39 @@ func (t *PokerTable) betBlinds() error {
 	if !t.Meta.GameStarted {
 		return ErrGameNotStarted
 	}
-	var smallBlindPlayer, bigBlindPlayer string
-	if len(t.Meta.PlayersOrder) > 2 {
-		smallBlindPlayer = t.Meta.PlayersOrder[(t.Meta.DealerIndex+1)%len(t.Meta.PlayersOrder)]
-		bigBlindPlayer = t.Meta.PlayersOrder[(t.Meta.DealerIndex+2)%len(t.Meta.PlayersOrder)]
-	} else {
-		smallBlindPlayer = t.Meta.PlayersOrder[t.Meta.DealerIndex] // дилер ставит малый блайнд в хендз апе
-		bigBlindPlayer = t.Meta.PlayersOrder[(t.Meta.DealerIndex+1)%len(t.Meta.PlayersOrder)]
+	if t.Config.ButtonBlind {
+		return t.betButtonBlind()
+	}
+	// место малого блайнда могло опустеть, тогда в раздаче только большой блайнд
+	smallBlindPlayerBet := 0
+	if smallBlindPlayer, ok := t.seatPlayer(t.Meta.SmallBlindSeat); ok {
+		smallBlindPlayerBet = min(t.Config.SmallBlind, t.Meta.Players[smallBlindPlayer].GetBalance())
+		t.putChips(t.Meta.Players[smallBlindPlayer], smallBlindPlayerBet)
+		t.notify(t.public(), EventSmallBlind, BlindPosted{PlayerId: smallBlindPlayer, Blind: BlindSmall, Amount: smallBlindPlayerBet})
 	}
 
-	smallBlindPlayerBet := min(t.Config.SmallBlind, t.Meta.Players[smallBlindPlayer].GetBalance())
-	t.Meta.Players[smallBlindPlayer].ChangeBalance(-smallBlindPlayerBet)
-	t.Meta.Players[smallBlindPlayer].SetLastBet(smallBlindPlayerBet)
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"small_blind", fmt.Sprintf("player %s bet %d as small blind", smallBlindPlayer, smallBlindPlayerBet), t.Config.TableId.String()})
-
+	bigBlindPlayer, _ := t.seatPlayer(t.Meta.BigBlindSeat)
 	bigBlindPlayerBet := min(t.Config.SmallBlind*2, t.Meta.Players[bigBlindPlayer].GetBalance())
-	t.Meta.Players[bigBlindPlayer].ChangeBalance(-bigBlindPlayerBet)
-	t.Meta.Players[bigBlindPlayer].SetLastBet(bigBlindPlayerBet)
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"big_blind", fmt.Sprintf("player %s bet %d as big blind", bigBlindPlayer, bigBlindPlayerBet), t.Config.TableId.String()})
+	t.putChips(t.Meta.Players[bigBlindPlayer], bigBlindPlayerBet)
+	t.notify(t.public(), EventBigBlind, BlindPosted{PlayerId: bigBlindPlayer, Blind: BlindBig, Amount: bigBlindPlayerBet})
 	t.Meta.CurrentBet = max(bigBlindPlayerBet, smallBlindPlayerBet)
+	t.Meta.LastRaise = t.betSize()
+	t.Meta.RaisesCount = 1 // большой блайнд считается ставкой
+	if t.straddled() {
+		t.betStraddle()
+	}
+	return nil
+}
+
+// betButtonBlind - блайнд баттона в играх с анте: ставит только дилер, первым ходит следующий за ним
+func (t *PokerTable) betButtonBlind() error {
+	dealer, _ := t.seatPlayer(t.Meta.ButtonSeat)
+	bet := min(t.Config.SmallBlind*2, t.Meta.Players[dealer].GetBalance())
+	t.putChips(t.Meta.Players[dealer], bet)
+	t.notify(t.public(), EventButtonBlind, BlindPosted{PlayerId: dealer, Blind: BlindButton, Amount: bet})
+	t.Meta.CurrentBet = bet
+	t.Meta.LastRaise = t.betSize()
+	t.Meta.RaisesCount = 1
 	return nil
 }
 
@@ -432,9 +765,9 @@ func (t *PokerTable) getNextPlayer() {
 	for i := 1; i < len(t.Meta.PlayersOrder); i++ {
 		nextIndex := (t.Meta.PlayerTurnInd + i) % len(t.Meta.PlayersOrder)
 		nextPlayer := t.Meta.PlayersOrder[nextIndex]
-		if !t.Meta.Players[nextPlayer].GetFold() && !t.Meta.Players[nextPlayer].GetReadyStatus() {
+		if t.needsToAct(t.Meta.Players[nextPlayer]) {
 			t.Meta.PlayerTurnInd = nextIndex
-			t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"next_move", fmt.Sprintf("next move expect from %s player", nextPlayer), t.Config.TableId.String()})
+			t.notify(t.public(), EventNextMove, PlayerTurn{PlayerId: nextPlayer})
 			return
 		}
 	}
@@ -444,8 +777,9 @@ func (t *PokerTable) choiceDealer() error {
 	if !t.Meta.GameStarted {
 		return ErrGameNotStarted
 	}
-	t.Meta.DealerIndex = (t.Meta.DealerIndex + 1) % len(t.Meta.PlayersOrder)
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"dealer", fmt.Sprintf("dealer is %s", t.Meta.PlayersOrder[t.Meta.DealerIndex]), t.Config.TableId.String()})
+	t.Meta.ButtonSeat, t.Meta.SmallBlindSeat, t.Meta.BigBlindSeat = t.positions()
+	dealer, _ := t.seatPlayer(t.Meta.ButtonSeat) // пустой, если баттон мертвый
+	t.notify(t.public(), EventDealer, ButtonMoved{PlayerId: dealer, Seat: t.Meta.ButtonSeat})
 	return nil
 }
 
@@ -463,18 +797,49 @@ func (t *PokerTable) choiceFirstMovePlayer() error {
 	if !t.Meta.GameStarted {
 		return ErrGameNotStarted
 	}
-	if t.Meta.CurrentRound == 0 { //utg
-		t.Meta.PlayerTurnInd = (t.Meta.DealerIndex + 3) % len(t.Meta.PlayersOrder)
+	var first int
+	if t.Meta.CurrentRound == 0 && t.Config.ButtonBlind {
+		first = t.afterSeat(t.Meta.ButtonSeat, 1)
+	} else if t.Meta.CurrentRound == 0 && t.straddled() { // следующий за страддлом
+		first = t.afterSeat(t.Meta.BigBlindSeat, 2)
+	} else if t.Meta.CurrentRound == 0 { //utg
+		first = t.afterSeat(t.Meta.BigBlindSeat, 1)
+		if t.activePlayers() == 2 { // в хендз апе префлоп начинает большой блайнд
+			first = t.afterSeat(t.Meta.BigBlindSeat, 2)
+		}
 	} else {
-		t.Meta.PlayerTurnInd = (t.Meta.DealerIndex + 1) % len(t.Meta.PlayersOrder)
+		first = t.afterSeat(t.Meta.ButtonSeat, 1)
 	}
+	// ход переходит к первому игроку, который еще может действовать
+	for i := 0; i < len(t.Meta.PlayersOrder); i++ {
+		ind := (first + i) % len(t.Meta.PlayersOrder)
+		if t.needsToAct(t.Meta.Players[t.Meta.PlayersOrder[ind]]) {
+			first = ind
+			break
+		}
+	}
+	t.Meta.PlayerTurnInd = first
 	return nil
 }
 
 func (t *PokerTable) MakeMove(playerId, action string, amount int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	err := t.makeMove(playerId, action, amount)
+	if err == nil {
+		delete(t.Meta.Timeouts, playerId) // игрок вернулся к столу
+	}
+	return err
+}
+
+func (t *PokerTable) makeMove(playerId, action string, amount int) error {
 	if !t.Meta.GameStarted {
 		return ErrGameNotStarted
 	}
+	if t.runItPending() {
+		t.notify([]string{playerId}, EventBadMove, newBadMove(playerId, action, amount, ErrRunItVoting))
+		return ErrRunItVoting
+	}
 
 	if t.Meta.PlayersOrder[t.Meta.PlayerTurnInd] != playerId {
 		return ErrNotYourTurn
@@ -487,19 +852,24 @@ func (t *PokerTable) MakeMove(playerId, action string, amount int) error {
 	switch action {
 	case "check":
 		err = t.handleCheck(playerId)
+	case "bet":
+		err = t.handleBet(playerId, amount)
 	case "raise":
 		err = t.handleRaise(playerId, amount)
 	case "call":
 		err = t.handleCall(playerId)
 	case "fold":
 		err = t.handleFold(playerId)
+	case "allin":
+		err = t.handleAllIn(playerId)
 	default:
 		err = ErrUnexpectedAction
 	}
 	if err != nil {
-		t.NotifyObservers([]string{playerId}, ObserverMessage{"bad_move", err.Error(), t.Config.TableId.String()})
+		t.notify([]string{playerId}, EventBadMove, newBadMove(playerId, action, amount, err))
 		return err
 	}
+	t.chargeTimeBank(playerId)
 	t.Meta.Players[playerId].SetStatus(true)
 	t.getNextPlayer()
 	if t.checkReady() {
@@ -515,57 +885,61 @@ func (t *PokerTable) notifyNext() error {
 		return ErrGameNotStarted
 	}
 	pId := t.Meta.PlayersOrder[t.Meta.PlayerTurnInd]
-	if t.Meta.CurrentBet != 0 {
-		t.NotifyObservers([]string{pId}, ObserverMessage{"can_do", fmt.Sprintf("player %s can do call with %d", pId, t.Meta.CurrentBet), t.Config.TableId.String()})
-	} else {
-		t.NotifyObservers([]string{pId}, ObserverMessage{"can_do", fmt.Sprintf("player %s can do check", pId), t.Config.TableId.String()})
+	if t.Meta.Leaving[pId] { // игрок ушел, за него сразу делается fold
+		return t.makeMove(pId, "fold", 0)
 	}
+	t.startClock(pId)
+	t.notify([]string{pId}, EventCanDo, t.availableAction(pId))
 	return nil
 }
 
+// availableAction - что игрок может сделать, не повышая ставку
+func (t *PokerTable) availableAction(playerId string) AvailableAction {
+	if t.Meta.CurrentBet != 0 {
+		return AvailableAction{PlayerId: playerId, Action: "call", Amount: t.Meta.CurrentBet}
+	}
+	return AvailableAction{PlayerId: playerId, Action: "check"}
+}
+
 func (t *PokerTable) checkReady() bool {
 	if !t.Meta.GameStarted {
 		return false
 	}
 
-	activePlayers := 0
 	notFoldedPlayers := 0
-	actedPlayers := 0
+	activePlayers := 0
+	waitingPlayers := 0
+	owingPlayers := 0
 
 	for _, player := range t.Meta.Players {
-		if player.GetBalance() < 0 {
+		if player.GetFold() {
 			continue
 		}
-
-		if player.GetBalance() > 0 {
-			activePlayers++
+		notFoldedPlayers++
+		if player.GetBalance() == 0 { // all in
+			continue
 		}
-
-		if !player.GetFold() {
-			notFoldedPlayers++
+		activePlayers++
+		if t.needsToAct(player) {
+			waitingPlayers++
 		}
-
-		if player.GetReadyStatus() {
-			actedPlayers++
+		if player.GetLastBet() < t.Meta.CurrentBet {
+			owingPlayers++
 		}
 	}
-	fmt.Println(actedPlayers, activePlayers, notFoldedPlayers, len(t.Meta.Players))
-	// Все сделали ходы
-	if actedPlayers == len(t.Meta.Players) {
-		return true
-	}
-
-	// Остался один активный игрок
-	if activePlayers <= 1 {
-		return true
-	}
 
 	// Остался один не сбросивший карты
 	if notFoldedPlayers <= 1 {
 		return true
 	}
 
-	return false
+	// Остальные в all in: последнему с фишками достаточно уравнять ставку
+	if activePlayers <= 1 {
+		return owingPlayers == 0
+	}
+
+	// Все сделали ходы и уравняли ставку
+	return waitingPlayers == 0
 }
 
 func (t *PokerTable) handleCheck(playerId string) error {
@@ -576,14 +950,18 @@ func (t *PokerTable) handleCheck(playerId string) error {
 		return ErrPlayerIsFold
 	}
 
-	if t.Meta.CurrentBet != 0 {
+	if !t.canCheck(playerId) {
 		return ErrCantCheck
 	}
 	t.Meta.Players[playerId].SetStatus(true)
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"do", fmt.Sprintf("player %s do check", playerId), t.Config.TableId.String()})
+	t.notify(t.public(), EventDo, PlayerAction{PlayerId: playerId, Action: "check", Amount: t.Meta.Players[playerId].GetLastBet()})
 	return nil
 }
 
+func (t *PokerTable) canCheck(playerId string) bool {
+	return t.Meta.Players[playerId].GetLastBet() >= t.Meta.CurrentBet
+}
+
 func (t *PokerTable) handleFold(playerId string) error {
 	if !t.Meta.GameStarted {
 		return ErrGameNotStarted
@@ -591,31 +969,56 @@ func (t *PokerTable) handleFold(playerId string) error {
 
 	t.Meta.Players[playerId].SetStatus(true)
 	t.Meta.Players[playerId].SetFold(true)
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"do", fmt.Sprintf("player %s do fold", playerId), t.Config.TableId.String()})
+	t.notify(t.public(), EventDo, PlayerAction{PlayerId: playerId, Action: "fold", Amount: t.Meta.Players[playerId].GetLastBet()})
 	return nil
 }
 
+// handleBet открывает торговлю на улице. amount - итоговая ставка игрока на улице
+func (t *PokerTable) handleBet(playerId string, amount int) error {
+	if !t.Meta.GameStarted {
+		return ErrGameNotStarted
+	}
+	if t.Meta.CurrentBet != 0 {
+		return ErrCantBet
+	}
+	return t.raiseTo(playerId, amount, "bet")
+}
+
+// handleRaise повышает текущую ставку до amount. Если ставки на улице еще нет, то это bet
 func (t *PokerTable) handleRaise(playerId string, amount int) error {
 	if !t.Meta.GameStarted {
 		return ErrGameNotStarted
 	}
-	if t.Meta.Players[playerId].GetFold() {
+	if t.Meta.CurrentBet == 0 {
+		return t.raiseTo(playerId, amount, "bet")
+	}
+	return t.raiseTo(playerId, amount, "raise")
+}
+
+func (t *PokerTable) raiseTo(playerId string, amount int, action string) error {
+	p := t.Meta.Players[playerId]
+	if p.GetFold() {
 		return ErrPlayerIsFold
 	}
-	if !(amount >= t.Meta.CurrentBet*2 && amount > t.Meta.Players[playerId].GetLastBet() && amount > 0) {
-		return ErrCantRaise
+	if p.GetReadyStatus() {
+		return ErrActionClosed
 	}
-	delta := amount - t.Meta.Players[playerId].GetLastBet()
-	if delta > t.Meta.Players[playerId].GetBalance() {
+	delta := amount - p.GetLastBet()
+	if delta > p.GetBalance() {
 		return ErrNotEnoughMoney
 	}
+	if err := t.checkRaise(p, amount, false); err != nil {
+		return err
+	}
 	t.resetPlayersStatus()
-	t.Meta.Players[playerId].SetLastBet(amount)
-	t.Meta.Players[playerId].ChangeBalance(-delta)
-	t.Meta.Players[playerId].SetStatus(true)
+	t.putChips(p, delta)
+	p.SetStatus(true)
+	t.Meta.LastRaise = amount - t.Meta.CurrentBet
 	t.Meta.CurrentBet = amount
+	t.Meta.RaisesCount++
+	t.Meta.LastAggressor = playerId
 
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{"do", fmt.Sprintf("player %s do raise with %d amount", playerId, amount), t.Config.TableId.String()})
+	t.notify(t.public(), EventDo, PlayerAction{PlayerId: playerId, Action: action, Amount: amount})
 	return nil
 }
 
@@ -636,6 +1039,7 @@ func refreshPlayers(players map[string]IPlayer, fold bool) error {
 	for _, v := range players {
 		if fold {
 			v.SetFold(false)
+			v.SetTotalBet(0)
 		}
 		v.SetLastBet(0)
 		v.SetStatus(false)
@@ -655,21 +1059,51 @@ func (t *PokerTable) handleCall(playerId string) error {
 		return ErrPlayerIsFold
 	}
 	needToBet := t.Meta.CurrentBet - t.Meta.Players[playerId].GetLastBet()
-	possibleBet := min(needToBet, t.Meta.Players[playerId].GetBalance())
+	if needToBet > t.Meta.Players[playerId].GetBalance() { // на неполный колл нужно идти all in
+		return ErrNotEnoughMoney
+	}
 
-	t.Meta.Players[playerId].ChangeBalance(-possibleBet)
+	t.putChips(t.Meta.Players[playerId], needToBet)
 	t.Meta.Players[playerId].SetStatus(true)
-	if t.Meta.Players[playerId].GetBalance() > 0 {
-		t.Meta.Players[playerId].SetLastBet(t.Meta.CurrentBet)
-	} else {
-		t.Meta.Players[playerId].SetLastBet(possibleBet)
+
+	t.notify(t.public(), EventDo, PlayerAction{PlayerId: playerId, Action: "call", Amount: t.Meta.CurrentBet})
+	return nil
+}
+
+func (t *PokerTable) handleAllIn(playerId string) error {
+	if !t.Meta.GameStarted {
+		return ErrGameNotStarted
+	}
+	p := t.Meta.Players[playerId]
+	if p.GetFold() {
+		return ErrPlayerIsFold
+	}
+	stack := p.GetBalance()
+	if stack == 0 {
+		return ErrNotEnoughMoney
+	}
+	allInBet := p.GetLastBet() + stack
+	if allInBet > t.Meta.CurrentBet {
+		if p.GetReadyStatus() {
+			return ErrActionClosed
+		}
+		if err := t.checkRaise(p, allInBet, true); err != nil {
+			return err
+		}
+	}
+	t.putChips(p, stack)
+	if raise := allInBet - t.Meta.CurrentBet; raise > 0 {
+		// неполный рейз в all in не открывает торговлю заново для тех, кто уже сделал ход
+		if raise >= t.Meta.LastRaise {
+			t.resetPlayersStatus()
+			t.Meta.LastRaise = raise
+			t.Meta.RaisesCount++
+		}
+		t.Meta.CurrentBet = allInBet
+		t.Meta.LastAggressor = playerId
 	}
+	p.SetStatus(true)
 
-	t.NotifyObservers(t.Meta.PlayersOrder, ObserverMessage{
-		"do",
-		fmt.Sprintf("player %s do call with %d amount", playerId, t.Meta.CurrentBet),
-		t.Config.TableId.String(),
-	},
-	)
+	t.notify(t.public(), EventDo, PlayerAction{PlayerId: playerId, Action: "allin", Amount: p.GetLastBet()})
 	return nil
 }
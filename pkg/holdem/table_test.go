@@ -128,3 +128,241 @@ func TestTableGame3Good(t *testing.T) {
 		require.Equal(t, p2.Balance, 1100)
 	})
 }
+
+func TestTableMoveTimeout(t *testing.T) {
+	// таймеры стоят на час, истечение хода вызывается напрямую
+	t.Run("auto fold after time bank", func(t *testing.T) {
+		config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+		config.MoveTimeout = time.Hour
+		config.TimeBank = time.Hour
+		table := NewPokerTable(config)
+		rec := &eventRecorder{}
+		table.AddObserver(rec)
+		p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+		p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //sb
+		table.AddPlayer(p1)
+		table.AddPlayer(p2)
+		table.StartGame()
+
+		expire := func(p *Player) {
+			table.onClockExpired(p.GetId(), table.Meta.TurnId)
+			require.True(t, table.Meta.GameStarted)
+			// истекший ход с другим номером ничего не делает
+			table.onClockExpired(p.GetId(), table.Meta.TurnId-1)
+			require.Equal(t, table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], p.GetId())
+			table.onClockExpired(p.GetId(), table.Meta.TurnId)
+		}
+		expire(p1)
+		expire(p2)
+		require.False(t, table.Meta.GameStarted)
+		require.Len(t, rec.byType(EventTimeBank), 2)
+		require.Equal(t, rec.byType(EventTimeout), []any{
+			PlayerTimeout{PlayerId: p1.GetId(), Action: "check"},
+			PlayerTimeout{PlayerId: p2.GetId(), Action: "fold"},
+		})
+
+		// p1 на большом блайнде и может сделать check, p2 должен доставить до 100 и сбрасывает
+		require.Equal(t, p1.Balance, 1050)
+		require.Equal(t, p2.Balance, 950)
+	})
+
+	t.Run("auto check when possible", func(t *testing.T) {
+		config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+		config.MoveTimeout = time.Hour
+		table := NewPokerTable(config)
+		rec := &eventRecorder{}
+		table.AddObserver(rec)
+		p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+		p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //sb
+		table.AddPlayer(p1)
+		table.AddPlayer(p2)
+		table.StartGame()
+		require.NoError(t, table.MakeMove(p1.GetId(), "call", 0))
+		require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+
+		require.Equal(t, table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], p1.GetId())
+		table.onClockExpired(p1.GetId(), table.Meta.TurnId)
+		require.Equal(t, rec.byType(EventTimeout), []any{PlayerTimeout{PlayerId: p1.GetId(), Action: "check"}})
+		require.Equal(t, table.Meta.CurrentRound, 1)
+		require.Equal(t, table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], p2.GetId())
+		require.False(t, p1.GetFold())
+	})
+
+	t.Run("player leaves on his turn", func(t *testing.T) {
+		config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+		config.MoveTimeout = time.Hour
+		table := NewPokerTable(config)
+		rec := &eventRecorder{}
+		table.AddObserver(rec)
+		p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000} //bb
+		p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+		p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+		table.AddPlayer(p1)
+		table.AddPlayer(p2)
+		table.AddPlayer(p3)
+		table.StartGame()
+
+		turnId := table.Meta.TurnId
+		require.NoError(t, table.RemovePlayer(p2.GetId()))
+		require.Equal(t, rec.byType(EventDo), []any{PlayerAction{PlayerId: p2.GetId(), Action: "fold"}})
+		require.Equal(t, table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], p3.GetId())
+		require.NotEqual(t, table.Meta.TurnId, turnId)
+
+		// таймер ушедшего игрока ничего не делает
+		table.onClockExpired(p2.GetId(), turnId)
+		table.onClockExpired(p2.GetId(), table.Meta.TurnId)
+		require.Empty(t, rec.byType(EventTimeout))
+		require.Equal(t, table.Meta.PlayersOrder[table.Meta.PlayerTurnInd], p3.GetId())
+
+		// ушел и большой блайнд, раздача достается p3
+		require.NoError(t, table.RemovePlayer(p1.GetId()))
+		require.False(t, table.Meta.GameStarted)
+		require.Equal(t, p1.Balance, 900)
+		require.Equal(t, p3.Balance, 1100)
+	})
+}
+
+func TestTableAllIn(t *testing.T) {
+	newTable := func() (*PokerTable, *Player, *Player, *Player) {
+		config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+		table := NewPokerTable(config)
+		p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 300}  //bb
+		p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+		p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+		table.AddPlayer(p1)
+		table.AddPlayer(p2)
+		table.AddPlayer(p3)
+		table.StartGame()
+		return table, p1, p2, p3
+	}
+
+	t.Run("short all in creates side pot", func(t *testing.T) {
+		table, p1, p2, p3 := newTable()
+		require.NoError(t, table.MakeMove(p2.GetId(), "raise", 400))
+		require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+		require.ErrorIs(t, table.MakeMove(p1.GetId(), "call", 0), ErrNotEnoughMoney)
+		require.NoError(t, table.MakeMove(p1.GetId(), "allin", 0))
+
+		require.Equal(t, table.Meta.CurrentRound, 1)
+		require.Len(t, table.Meta.Pots, 2)
+		require.Equal(t, table.Meta.Pots[0].Amount, 900)
+		require.ElementsMatch(t, table.Meta.Pots[0].Applicants, []string{p1.GetId(), p2.GetId(), p3.GetId()})
+		require.Equal(t, table.Meta.Pots[1].Amount, 200)
+		require.ElementsMatch(t, table.Meta.Pots[1].Applicants, []string{p2.GetId(), p3.GetId()})
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, table.MakeMove(p3.GetId(), "check", 0))
+			require.NoError(t, table.MakeMove(p2.GetId(), "check", 0))
+		}
+		require.Equal(t, table.Meta.GameStarted, false)
+		require.Equal(t, p1.Balance+p2.Balance+p3.Balance, 2300)
+		require.LessOrEqual(t, p1.Balance, 900)
+	})
+
+	t.Run("uncalled bet returns to bettor", func(t *testing.T) {
+		table, p1, p2, p3 := newTable()
+		require.NoError(t, table.MakeMove(p2.GetId(), "raise", 400))
+		require.NoError(t, table.MakeMove(p3.GetId(), "fold", 0))
+		require.NoError(t, table.MakeMove(p1.GetId(), "fold", 0))
+
+		require.Equal(t, table.Meta.GameStarted, false)
+		require.Equal(t, p1.Balance, 200)
+		require.Equal(t, p2.Balance, 1150)
+		require.Equal(t, p3.Balance, 950)
+	})
+
+	t.Run("everyone all in runs out the board", func(t *testing.T) {
+		table, p1, p2, p3 := newTable()
+		require.NoError(t, table.MakeMove(p2.GetId(), "allin", 0))
+		require.NoError(t, table.MakeMove(p3.GetId(), "allin", 0))
+		require.NoError(t, table.MakeMove(p1.GetId(), "allin", 0))
+
+		require.Equal(t, table.Meta.GameStarted, false)
+		require.Equal(t, p1.Balance+p2.Balance+p3.Balance, 2300)
+	})
+}
+
+func TestTableRaiseRules(t *testing.T) {
+	newTable := func() (*PokerTable, *Player, *Player, *Player) {
+		config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+		table := NewPokerTable(config)
+		p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 300}  //bb
+		p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+		p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+		table.AddPlayer(p1)
+		table.AddPlayer(p2)
+		table.AddPlayer(p3)
+		table.StartGame()
+		return table, p1, p2, p3
+	}
+
+	t.Run("min raise equals previous raise", func(t *testing.T) {
+		table, _, p2, p3 := newTable()
+		require.ErrorIs(t, table.MakeMove(p2.GetId(), "raise", 150), ErrCantRaise)
+		require.NoError(t, table.MakeMove(p2.GetId(), "raise", 300))
+		require.Equal(t, table.Meta.LastRaise, 200)
+		require.ErrorIs(t, table.MakeMove(p3.GetId(), "raise", 400), ErrCantRaise)
+		require.NoError(t, table.MakeMove(p3.GetId(), "raise", 500))
+		require.Equal(t, table.Meta.CurrentBet, 500)
+		require.Equal(t, table.Meta.LastRaise, 200)
+	})
+
+	t.Run("bet and raise", func(t *testing.T) {
+		table, p1, p2, p3 := newTable()
+		require.ErrorIs(t, table.MakeMove(p2.GetId(), "bet", 200), ErrCantBet)
+		require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+		require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+		require.NoError(t, table.MakeMove(p1.GetId(), "check", 0))
+
+		require.Equal(t, table.Meta.CurrentRound, 1)
+		require.ErrorIs(t, table.MakeMove(p3.GetId(), "bet", 50), ErrCantRaise)
+		require.NoError(t, table.MakeMove(p3.GetId(), "bet", 100))
+		require.ErrorIs(t, table.MakeMove(p1.GetId(), "bet", 200), ErrCantBet)
+		require.NoError(t, table.MakeMove(p1.GetId(), "raise", 200))
+	})
+
+	t.Run("short all in does not reopen action", func(t *testing.T) {
+		table, p1, p2, p3 := newTable()
+		require.NoError(t, table.MakeMove(p2.GetId(), "raise", 250))
+		require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+		require.NoError(t, table.MakeMove(p1.GetId(), "allin", 0))
+		require.Equal(t, table.Meta.CurrentBet, 300)
+		require.Equal(t, table.Meta.LastRaise, 150)
+
+		require.ErrorIs(t, table.MakeMove(p2.GetId(), "raise", 600), ErrActionClosed)
+		require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+		require.ErrorIs(t, table.MakeMove(p3.GetId(), "allin", 0), ErrActionClosed)
+		require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+		require.Equal(t, table.Meta.CurrentRound, 1)
+	})
+}
+
+func TestTableRemovePlayerMidHand(t *testing.T) {
+	config := NewTableConfig(time.Hour, 10, 2, 50, 0, 0, false, 1488)
+	table := NewPokerTable(config)
+	p1 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Balance: 1000}
+	p2 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Balance: 1000} //dealer
+	p3 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Balance: 1000} //sb
+	p4 := &Player{Id: uuid.MustParse("00000000-0000-0000-0000-000000000004"), Balance: 1000} //bb
+	table.AddPlayer(p1)
+	table.AddPlayer(p2)
+	table.AddPlayer(p3)
+	table.AddPlayer(p4)
+	table.StartGame()
+	require.NoError(t, table.MakeMove(p1.GetId(), "raise", 400))
+	require.NoError(t, table.MakeMove(p2.GetId(), "call", 0))
+	require.NoError(t, table.MakeMove(p3.GetId(), "call", 0))
+	require.NoError(t, table.MakeMove(p4.GetId(), "call", 0))
+
+	// p4 уходит на флопе, его 400 остаются в банке
+	require.NoError(t, table.RemovePlayer(p4.GetId()))
+	require.Equal(t, table.potSize(), 1600)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, table.MakeMove(p3.GetId(), "check", 0))
+		require.NoError(t, table.MakeMove(p1.GetId(), "check", 0))
+		require.NoError(t, table.MakeMove(p2.GetId(), "check", 0))
+	}
+	require.False(t, table.Meta.GameStarted)
+	require.Equal(t, p4.Balance, 600)
+	require.Equal(t, p1.Balance+p2.Balance+p3.Balance+p4.Balance, 4000)
+}
@@ -79,8 +79,27 @@ func (s *Server) CreateApp() *fiber.App {
 		lobby.Get("/all/:page", s.handler.GetAllLobbies)
 		lobby.Post("/", s.handler.CreateLobby)
 	}
+
+	equity := app.Group("/equity", s.handler.CheckAuthMiddleware)
+	{
+		equity.Post("/", s.handler.CalculateEquity)
+	}
+
+	shuffle := app.Group("/shuffle", s.handler.CheckAuthMiddleware)
+	{
+		shuffle.Post("/verify", s.handler.VerifyShuffle)
+	}
+
+	hands := app.Group("/hands", s.handler.CheckAuthMiddleware)
+	{
+		hands.Get("/all/:page", s.handler.GetMyHands)
+		hands.Get("/:id", s.handler.GetHand)
+		hands.Get("/:id/pokerstars", s.handler.ExportHand)
+		hands.Get("/:id/replay", s.handler.ReplayHand)
+	}
 	{
 		app.Get("ws/enter", websocket.New(s.handler.EnterInLobby))
+		app.Get("ws/watch", websocket.New(s.handler.WatchLobby))
 	}
 
 	return app